@@ -0,0 +1,5 @@
+data "ceph_crush_rules" "all" {}
+
+output "crush_rule_names" {
+  value = [for rule in data.ceph_crush_rules.all.rules : rule.name]
+}
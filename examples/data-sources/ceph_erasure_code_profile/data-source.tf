@@ -0,0 +1,3 @@
+data "ceph_erasure_code_profile" "default" {
+  name = "default"
+}
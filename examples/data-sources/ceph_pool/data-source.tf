@@ -0,0 +1,7 @@
+data "ceph_pool" "rbd" {
+  name = "rbd"
+}
+
+output "rbd_pool_percent_used" {
+  value = data.ceph_pool.rbd.stats.percent_used
+}
@@ -0,0 +1,8 @@
+data "ceph_pools" "rbd" {
+  application = "rbd"
+  name_regex  = "^k8s-"
+}
+
+output "rbd_pools" {
+  value = [for pool in data.ceph_pools.rbd.pools : pool.name]
+}
@@ -0,0 +1,7 @@
+data "ceph_rbd_namespaces" "rbd" {
+  pool = "rbd"
+}
+
+output "namespace_image_counts" {
+  value = { for ns in data.ceph_rbd_namespaces.rbd.namespaces : ns.name => ns.image_count }
+}
@@ -0,0 +1,13 @@
+provider "ceph" {
+  endpoint = "https://ceph-mgr.example.com:8443"
+  username = "admin"
+  password = var.ceph_password
+
+  # Needed by resources using mon commands the Dashboard does not expose,
+  # such as ceph_pool_snapshot. Create the key with `ceph restful create-key`.
+  restful = {
+    endpoint = "https://ceph-mgr.example.com:8003"
+    username = "terraform"
+    api_key  = var.ceph_restful_api_key
+  }
+}
@@ -0,0 +1,11 @@
+resource "ceph_crush_rule" "ssd" {
+  name           = "replicated_ssd"
+  root           = "default"
+  failure_domain = "host"
+  device_class   = "ssd"
+}
+
+resource "ceph_pool" "fast" {
+  name       = "fast-pool"
+  crush_rule = ceph_crush_rule.ssd.name
+}
@@ -0,0 +1,16 @@
+resource "ceph_erasure_code_profile" "example" {
+  name                 = "ec-4-2"
+  k                    = 4
+  m                    = 2
+  plugin               = "jerasure"
+  technique            = "reed_sol_van"
+  crush_failure_domain = "host"
+  crush_device_class   = "hdd"
+}
+
+resource "ceph_pool" "example" {
+  name                 = "example-ec-pool"
+  pool_type            = "erasure"
+  erasure_code_profile = ceph_erasure_code_profile.example.name
+  allow_ec_overwrites  = true
+}
@@ -1,8 +1,54 @@
 resource "ceph_pool" "example" {
-  name        = "example-pool"
-  pool_type   = "replicated"
-  pg_num      = 128
-  pgp_num     = 128
-  size        = 3
-  application = "rbd"
-}
\ No newline at end of file
+  name                = "example-pool"
+  pool_type           = "replicated"
+  pg_num              = 128
+  pgp_num             = 128
+  pg_autoscale_mode   = "off"
+  size                = 3
+  min_size            = 2
+  applications        = ["rbd"]
+  quota_max_bytes     = "500GiB"
+  quota_max_objects   = 1000000
+  deletion_protection = true
+}
+
+# Placed on SSDs through the replicated_ssd CRUSH rule, created if missing
+resource "ceph_pool" "ssd" {
+  name               = "example-ssd-pool"
+  crush_device_class = "ssd"
+  applications       = ["rbd"]
+}
+
+resource "ceph_pool" "erasure" {
+  name                 = "example-ec-pool"
+  pool_type            = "erasure"
+  erasure_code_profile = "default"
+  allow_ec_overwrites  = true
+  applications         = ["rbd"]
+}
+
+resource "ceph_pool" "autoscaled" {
+  name              = "example-autoscaled-pool"
+  pg_autoscale_mode = "on"
+  target_size_ratio = 0.2
+  pg_num_min        = 16
+  pg_num_max        = 256
+  applications      = ["rgw"]
+
+  compression_mode           = "aggressive"
+  compression_algorithm      = "zstd"
+  compression_required_ratio = 0.875
+  compression_min_blob_size  = "8KiB"
+}
+
+# application_metadata needs the restful block of the provider
+resource "ceph_pool" "cephfs_data" {
+  name         = "example-cephfs-data"
+  applications = ["cephfs"]
+
+  application_metadata = {
+    cephfs = {
+      data = "example-fs"
+    }
+  }
+}
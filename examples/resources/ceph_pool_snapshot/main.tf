@@ -0,0 +1,6 @@
+# Pool snapshots need a pool without RBD or CephFS snapshots and the restful
+# block of the provider.
+resource "ceph_pool_snapshot" "before_migration" {
+  pool = ceph_pool.example.name
+  name = "before-migration"
+}
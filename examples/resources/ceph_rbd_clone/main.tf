@@ -0,0 +1,14 @@
+resource "ceph_rbd_clone" "vm" {
+  parent_snapshot = ceph_rbd_snapshot.golden.id
+  pool            = ceph_pool.example.name
+  name            = "vm-disk-1"
+  size            = "40GiB"
+}
+
+# Independent copy that no longer depends on the snapshot
+resource "ceph_rbd_clone" "copy" {
+  parent_snapshot = ceph_rbd_snapshot.golden.id
+  pool            = ceph_pool.example.name
+  name            = "vm-disk-2"
+  flatten         = true
+}
@@ -0,0 +1,21 @@
+resource "ceph_rbd_image" "example" {
+  pool     = ceph_pool.example.name
+  name     = "vm-disk-0"
+  size     = "20GiB"
+  features = ["layering", "exclusive-lock", "object-map", "fast-diff", "deep-flatten"]
+
+  qos {
+    iops_limit       = 2000
+    write_iops_limit = 1000
+    bps_limit        = "200MiB"
+    iops_burst       = 4000
+  }
+}
+
+# Metadata in a replicated pool, data in an erasure coded pool
+resource "ceph_rbd_image" "erasure" {
+  pool      = ceph_pool.example.name
+  name      = "backup-volume"
+  size      = "1TiB"
+  data_pool = ceph_pool.erasure.name
+}
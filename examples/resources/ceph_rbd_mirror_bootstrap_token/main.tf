@@ -0,0 +1,27 @@
+# Mirroring between two clusters, each managed through its own provider alias
+provider "ceph" {
+  alias    = "site_a"
+  endpoint = "https://ceph-a.example.com:8443"
+}
+
+provider "ceph" {
+  alias    = "site_b"
+  endpoint = "https://ceph-b.example.com:8443"
+}
+
+resource "ceph_rbd_mirror_pool" "site_a" {
+  provider    = ceph.site_a
+  pool        = "rbd"
+  mirror_mode = "image"
+}
+
+resource "ceph_rbd_mirror_pool" "site_b" {
+  provider    = ceph.site_b
+  pool        = "rbd"
+  mirror_mode = "image"
+}
+
+resource "ceph_rbd_mirror_bootstrap_token" "site_a" {
+  provider = ceph.site_a
+  pool     = ceph_rbd_mirror_pool.site_a.pool
+}
@@ -0,0 +1,4 @@
+resource "ceph_rbd_mirror_image" "vm" {
+  image             = ceph_rbd_image.example.id
+  schedule_interval = "1h"
+}
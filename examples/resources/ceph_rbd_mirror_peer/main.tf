@@ -0,0 +1,8 @@
+# Imports the token created on site A into site B; rx-tx also adds site B as
+# peer on site A
+resource "ceph_rbd_mirror_peer" "site_a" {
+  provider  = ceph.site_b
+  pool      = ceph_rbd_mirror_pool.site_b.pool
+  token     = ceph_rbd_mirror_bootstrap_token.site_a.token
+  direction = "rx-tx"
+}
@@ -0,0 +1,4 @@
+resource "ceph_rbd_mirror_pool" "rbd" {
+  pool        = ceph_pool.example.name
+  mirror_mode = "image"
+}
@@ -0,0 +1,11 @@
+resource "ceph_rbd_namespace" "k8s" {
+  pool      = ceph_pool.example.name
+  namespace = "k8s"
+}
+
+resource "ceph_rbd_image" "pvc" {
+  pool      = ceph_rbd_namespace.k8s.pool
+  namespace = ceph_rbd_namespace.k8s.namespace
+  name      = "pvc-0001"
+  size      = "10GiB"
+}
@@ -0,0 +1,11 @@
+# Default throttle for every image in the pool that does not override it
+resource "ceph_rbd_pool_config" "example" {
+  pool = ceph_pool.example.name
+
+  qos {
+    iops_limit      = 500
+    read_bps_limit  = "100MiB"
+    write_bps_limit = "50MiB"
+    bps_burst       = "200MiB"
+  }
+}
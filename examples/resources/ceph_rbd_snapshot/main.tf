@@ -0,0 +1,13 @@
+resource "ceph_rbd_snapshot" "golden" {
+  image     = ceph_rbd_image.example.id
+  name      = "golden"
+  protected = true
+}
+
+# Changing rollback_trigger rolls the image back to the snapshot, e.g.
+# terraform apply -var rollback_serial=2
+resource "ceph_rbd_snapshot" "checkpoint" {
+  image            = ceph_rbd_image.example.id
+  name             = "checkpoint"
+  rollback_trigger = var.rollback_serial
+}
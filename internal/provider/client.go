@@ -2,21 +2,156 @@ package provider
 
 import (
     "bytes"
+    "context"
     "crypto/tls"
+    "crypto/x509"
     "encoding/json"
+    "errors"
     "fmt"
     "io"
     "net/http"
+    "net/url"
+    "os"
+    "reflect"
+    "sort"
+    "strings"
+    "sync"
     "time"
 )
 
+const (
+    // defaultTaskPollInterval is how often the task list is polled while
+    // waiting for a background task to finish
+    defaultTaskPollInterval = 2 * time.Second
+    
+    // authCheckInterval is how long a token is trusted before it is
+    // re-validated against /api/auth/check
+    authCheckInterval = 5 * time.Minute
+)
+
+// errPoolNotFound is returned by GetPool and GetPoolByID when the pool does
+// not exist
+var errPoolNotFound = errors.New("pool not found")
+
 // CephClient handles communication with Ceph API
 type CephClient struct {
+    // Endpoint is the Dashboard URL currently believed to be served by the
+    // active mgr, one of Endpoints
     Endpoint   string
+    Endpoints  []string
     Username   string
     Password   string
     Token      string
     HTTPClient *http.Client
+
+    // TaskPollInterval controls how often background tasks are polled
+    TaskPollInterval time.Duration
+    
+    // Retry controls how transient failures are retried
+    Retry RetryPolicy
+    
+    // mu guards Token and tokenCheckedAt, which are shared by Terraform's
+    // parallel resource operations
+    mu             sync.Mutex
+    tokenCheckedAt time.Time
+    
+    // endpointMu guards Endpoint, which changes on mgr failover
+    endpointMu sync.RWMutex
+    
+    // ServerVersion is the Ceph release detected by DetectVersion
+    ServerVersion *CephVersion
+    versionMu     sync.RWMutex
+    
+    // ToggleMonAllowPoolDelete enables mon_allow_pool_delete around pool
+    // deletions, which poolDeleteMu serializes
+    ToggleMonAllowPoolDelete bool
+    poolDeleteMu             sync.Mutex
+    
+    // Restful is the mgr restful module, which runs the mon commands the
+    // Dashboard API does not expose. Nil unless configured.
+    Restful *RestfulConfig
+}
+
+// TLSConfig describes how the client verifies the Dashboard certificate and
+// authenticates itself at the TLS layer
+type TLSConfig struct {
+    // Insecure disables verification of the Dashboard certificate
+    Insecure bool
+    
+    // CACertFile and CACertPEM add trusted CA certificates, read from a
+    // file or given inline, on top of the system pool
+    CACertFile string
+    CACertPEM  string
+    
+    // ClientCertFile/ClientKeyFile or ClientCertPEM/ClientKeyPEM hold the
+    // client certificate used for mutual TLS
+    ClientCertFile string
+    ClientKeyFile  string
+    ClientCertPEM  string
+    ClientKeyPEM   string
+    
+    // ServerName overrides the name used to verify the Dashboard certificate
+    ServerName string
+}
+
+// Build converts the configuration into a *tls.Config
+func (t TLSConfig) Build() (*tls.Config, error) {
+    tlsConfig := &tls.Config{
+        InsecureSkipVerify: t.Insecure,
+        ServerName:         t.ServerName,
+        MinVersion:         tls.VersionTLS12,
+    }
+    
+    if t.CACertFile != "" || t.CACertPEM != "" {
+        pool, err := x509.SystemCertPool()
+        if err != nil || pool == nil {
+            pool = x509.NewCertPool()
+        }
+        
+        if t.CACertFile != "" {
+            pem, err := os.ReadFile(t.CACertFile)
+            if err != nil {
+                return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+            }
+            if !pool.AppendCertsFromPEM(pem) {
+                return nil, fmt.Errorf("no PEM certificates found in CA certificate file %s", t.CACertFile)
+            }
+        }
+        
+        if t.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(t.CACertPEM)) {
+            return nil, fmt.Errorf("no PEM certificates found in inline CA certificate")
+        }
+        
+        tlsConfig.RootCAs = pool
+    }
+    
+    certPEM, keyPEM := []byte(t.ClientCertPEM), []byte(t.ClientKeyPEM)
+    if t.ClientCertFile != "" {
+        var err error
+        if certPEM, err = os.ReadFile(t.ClientCertFile); err != nil {
+            return nil, fmt.Errorf("failed to read client certificate file: %w", err)
+        }
+    }
+    if t.ClientKeyFile != "" {
+        var err error
+        if keyPEM, err = os.ReadFile(t.ClientKeyFile); err != nil {
+            return nil, fmt.Errorf("failed to read client key file: %w", err)
+        }
+    }
+    
+    if len(certPEM) > 0 || len(keyPEM) > 0 {
+        if len(certPEM) == 0 || len(keyPEM) == 0 {
+            return nil, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+        }
+        
+        cert, err := tls.X509KeyPair(certPEM, keyPEM)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load client certificate: %w", err)
+        }
+        tlsConfig.Certificates = []tls.Certificate{cert}
+    }
+    
+    return tlsConfig, nil
 }
 
 // AuthRequest is the login request structure
@@ -30,49 +165,275 @@ type AuthResponse struct {
     Token string `json:"token"`
 }
 
+// AuthCheckResponse is returned by /api/auth/check; Username is only set
+// when the checked token is valid
+type AuthCheckResponse struct {
+    Username string `json:"username"`
+}
+
+// Pool types
+const (
+    poolTypeReplicated = "replicated"
+    poolTypeErasure    = "erasure"
+)
+
+// Pool flags
+const (
+    // poolFlagEcOverwrites is set by allow_ec_overwrites
+    poolFlagEcOverwrites = "ec_overwrites"
+
+    // poolFlagSelfManagedSnaps is set once a client such as RBD manages the
+    // snapshots of the pool, which rules out pool snapshots
+    poolFlagSelfManagedSnaps = "selfmanaged_snaps"
+)
+
+// poolOptionUnset removes a string option of a pool when set as its value
+const poolOptionUnset = "unset"
+
+// BlueStore compression modes and algorithms
+var (
+    compressionModes      = []string{"none", "passive", "aggressive", "force"}
+    compressionAlgorithms = []string{"snappy", "zlib", "zstd", "lz4"}
+)
+
+// PG autoscale modes
+const (
+    pgAutoscaleModeOn   = "on"
+    pgAutoscaleModeWarn = "warn"
+    pgAutoscaleModeOff  = "off"
+)
+
 // PoolCreateRequest is the structure for creating a pool
 type PoolCreateRequest struct {
-    Pool        string `json:"pool"`
-    PoolType    string `json:"pool_type"`
-    PgNum       int    `json:"pg_num,omitempty"`
-    PgpNum      int    `json:"pgp_num,omitempty"`
-    Size        int    `json:"size,omitempty"`
-    Application string `json:"application,omitempty"`
+    Pool               string   `json:"pool"`
+    PoolType           string   `json:"pool_type"`
+    PgNum              int      `json:"pg_num,omitempty"`
+    PgpNum             int      `json:"pgp_num,omitempty"`
+    Size               int      `json:"size,omitempty"`
+    MinSize            int64    `json:"min_size,omitempty"`
+    Applications       []string `json:"application_metadata,omitempty"`
+    ErasureCodeProfile string   `json:"erasure_code_profile,omitempty"`
+    Flags              []string `json:"flags,omitempty"`
+    RuleName           string   `json:"rule_name,omitempty"`
+    QuotaMaxBytes      int64    `json:"quota_max_bytes,omitempty"`
+    QuotaMaxObjects    int64    `json:"quota_max_objects,omitempty"`
+    PgAutoscaleMode    string   `json:"pg_autoscale_mode,omitempty"`
+    TargetSizeBytes    int64    `json:"target_size_bytes,omitempty"`
+    TargetSizeRatio    float64  `json:"target_size_ratio,omitempty"`
+    PgNumMin           int64    `json:"pg_num_min,omitempty"`
+    PgNumMax           int64    `json:"pg_num_max,omitempty"`
+
+    CompressionMode          string  `json:"compression_mode,omitempty"`
+    CompressionAlgorithm     string  `json:"compression_algorithm,omitempty"`
+    CompressionRequiredRatio float64 `json:"compression_required_ratio,omitempty"`
+    CompressionMinBlobSize   int64   `json:"compression_min_blob_size,omitempty"`
+    CompressionMaxBlobSize   int64   `json:"compression_max_blob_size,omitempty"`
+}
+
+// Pool is a Ceph pool as returned by the Dashboard API
+type Pool struct {
+    ID                  int64                        `json:"pool"`
+    Name                string                       `json:"pool_name"`
+    Type                string                       `json:"type"`
+    Size                int64                        `json:"size"`
+    MinSize             int64                        `json:"min_size"`
+    CrushRule           string                       `json:"crush_rule"`
+    PgNum               int64                        `json:"pg_num"`
+    PgpNum              int64                        `json:"pgp_num"`
+    PgNumTarget         int64                        `json:"pg_num_target"`
+    PgpNumTarget        int64                        `json:"pgp_num_target"`
+    PgAutoscaleMode     string                       `json:"pg_autoscale_mode"`
+    ErasureCodeProfile  string                       `json:"erasure_code_profile"`
+    QuotaMaxBytes       int64                        `json:"quota_max_bytes"`
+    QuotaMaxObjects     int64                        `json:"quota_max_objects"`
+    Flags               int64                        `json:"flags"`
+    FlagsNames          string                       `json:"flags_names"`
+    Options             PoolOptions                  `json:"options"`
+    ApplicationMetadata map[string]map[string]string `json:"application_metadata"`
+    Stats               *PoolStats                   `json:"stats,omitempty"`
+    Snapshots           []PoolSnapshot               `json:"pool_snaps"`
+}
+
+// PoolSnapshot is a pool-level snapshot, as created by `ceph osd pool mksnap`
+type PoolSnapshot struct {
+    SnapID int64  `json:"snapid"`
+    Name   string `json:"name"`
+    Stamp  string `json:"stamp"`
+}
+
+// PoolOptions holds the per-pool options set through `ceph osd pool set`
+type PoolOptions struct {
+    CompressionMode          string  `json:"compression_mode,omitempty"`
+    CompressionAlgorithm     string  `json:"compression_algorithm,omitempty"`
+    CompressionRequiredRatio float64 `json:"compression_required_ratio,omitempty"`
+    CompressionMinBlobSize   int64   `json:"compression_min_blob_size,omitempty"`
+    CompressionMaxBlobSize   int64   `json:"compression_max_blob_size,omitempty"`
+    TargetSizeBytes          int64   `json:"target_size_bytes,omitempty"`
+    TargetSizeRatio          float64 `json:"target_size_ratio,omitempty"`
+    PgNumMin                 int64   `json:"pg_num_min,omitempty"`
+    PgNumMax                 int64   `json:"pg_num_max,omitempty"`
+}
+
+// PoolStats holds the usage statistics returned with stats=true
+type PoolStats struct {
+    BytesUsed   PoolStat `json:"bytes_used"`
+    MaxAvail    PoolStat `json:"max_avail"`
+    AvailRaw    PoolStat `json:"avail_raw"`
+    PercentUsed PoolStat `json:"percent_used"`
+    Stored      PoolStat `json:"stored"`
+    Objects     PoolStat `json:"objects"`
+    Rd          PoolStat `json:"rd"`
+    RdBytes     PoolStat `json:"rd_bytes"`
+    Wr          PoolStat `json:"wr"`
+    WrBytes     PoolStat `json:"wr_bytes"`
+}
+
+// PoolStat is a single statistic with its latest value and rate per second
+type PoolStat struct {
+    Latest float64 `json:"latest"`
+    Rate   float64 `json:"rate"`
+}
+
+// UnmarshalJSON decodes the pool payload of any supported Ceph release
+func (p *Pool) UnmarshalJSON(data []byte) error {
+    var raw map[string]interface{}
+    if err := json.Unmarshal(data, &raw); err != nil {
+        return err
+    }
+    normalizePool(raw)
+    
+    normalized, err := json.Marshal(raw)
+    if err != nil {
+        return err
+    }
+    
+    // The alias type drops this method to avoid recursing
+    type pool Pool
+    return json.Unmarshal(normalized, (*pool)(p))
+}
+
+// Applications returns the names of the applications enabled on the pool in
+// sorted order
+func (p *Pool) Applications() []string {
+    apps := make([]string, 0, len(p.ApplicationMetadata))
+    for app := range p.ApplicationMetadata {
+        apps = append(apps, app)
+    }
+    sort.Strings(apps)
+    return apps
+}
+
+// HasFlag reports whether the named flag, e.g. "ec_overwrites", is set
+func (p *Pool) HasFlag(flag string) bool {
+    for _, f := range strings.Split(p.FlagsNames, ",") {
+        if f == flag {
+            return true
+        }
+    }
+    return false
+}
+
+// HasApplication reports whether application is enabled on the pool
+func (p *Pool) HasApplication(application string) bool {
+    _, ok := p.ApplicationMetadata[application]
+    return ok
+}
+
+// Task describes a Dashboard background task as returned by a 202 Accepted
+// response or by the /api/task endpoint
+type Task struct {
+    Name      string                 `json:"name"`
+    Metadata  map[string]interface{} `json:"metadata"`
+    BeginTime string                 `json:"begin_time,omitempty"`
+    EndTime   string                 `json:"end_time,omitempty"`
+    Success   bool                   `json:"success,omitempty"`
+    Exception *TaskException         `json:"exception,omitempty"`
+}
+
+// TaskException holds the error reported by a failed background task
+type TaskException struct {
+    Detail    string `json:"detail"`
+    Component string `json:"component,omitempty"`
+}
+
+// TaskList is the response of the /api/task endpoint
+type TaskList struct {
+    ExecutingTasks []Task `json:"executing_tasks"`
+    FinishedTasks  []Task `json:"finished_tasks"`
+}
+
+// TaskError is returned when a background task finished unsuccessfully
+type TaskError struct {
+    Name   string
+    Detail string
+}
+
+func (e *TaskError) Error() string {
+    if e.Detail == "" {
+        return fmt.Sprintf("task %s failed", e.Name)
+    }
+    return fmt.Sprintf("task %s failed: %s", e.Name, e.Detail)
 }
 
 // NewCephClient creates a new Ceph API client
 func NewCephClient(endpoint, username, password string) *CephClient {
     return &CephClient{
-        Endpoint: endpoint,
-        Username: username,
-        Password: password,
+        Endpoint:  endpoint,
+        Endpoints: []string{endpoint},
+        Username:  username,
+        Password:  password,
         HTTPClient: &http.Client{
             Timeout: 30 * time.Second,
+            // Standby mgrs answer with 303 See Other, which must not be
+            // followed blindly as it turns a POST into a GET
+            CheckRedirect: func(*http.Request, []*http.Request) error {
+                return http.ErrUseLastResponse
+            },
             Transport: &http.Transport{
+                Proxy: http.ProxyFromEnvironment,
                 TLSClientConfig: &tls.Config{
-                    InsecureSkipVerify: true, // For self-signed certificates
+                    MinVersion: tls.VersionTLS12,
                 },
             },
         },
+        TaskPollInterval: defaultTaskPollInterval,
+        Retry:            DefaultRetryPolicy(),
+    }
+}
+
+// SetTLSConfig replaces the TLS settings used to reach the Dashboard
+func (c *CephClient) SetTLSConfig(cfg TLSConfig) error {
+    tlsConfig, err := cfg.Build()
+    if err != nil {
+        return err
+    }
+    
+    transport, ok := c.HTTPClient.Transport.(*http.Transport)
+    if !ok {
+        return fmt.Errorf("unexpected HTTP transport type %T", c.HTTPClient.Transport)
     }
+    
+    transport.TLSClientConfig = tlsConfig
+    return nil
 }
 
 // Authenticate logs in to Ceph and gets a token
-func (c *CephClient) Authenticate() error {
+func (c *CephClient) Authenticate(ctx context.Context) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    return c.authenticate(ctx)
+}
+
+// authenticate performs the login request; the caller must hold c.mu
+func (c *CephClient) authenticate(ctx context.Context) error {
     authReq := AuthRequest{
         Username: c.Username,
         Password: c.Password,
     }
     
     body, _ := json.Marshal(authReq)
-    req, err := http.NewRequest("POST", c.Endpoint+"/api/auth", bytes.NewBuffer(body))
-    if err != nil {
-        return fmt.Errorf("failed to create auth request: %w", err)
-    }
-    
-    req.Header.Set("Content-Type", "application/json")
-    
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.send(ctx, "POST", "/api/auth", body, "")
     if err != nil {
         return fmt.Errorf("authentication request failed: %w", err)
     }
@@ -89,32 +450,270 @@ func (c *CephClient) Authenticate() error {
     }
     
     c.Token = authResp.Token
+    c.tokenCheckedAt = time.Now()
     return nil
 }
 
-// CreatePool creates a new Ceph pool
-func (c *CephClient) CreatePool(poolReq PoolCreateRequest) error {
+// checkToken asks the Dashboard whether the current token is still valid;
+// the caller must hold c.mu
+func (c *CephClient) checkToken(ctx context.Context) (bool, error) {
+    body, _ := json.Marshal(map[string]string{"token": c.Token})
+    resp, err := c.send(ctx, "POST", "/api/auth/check?token="+url.QueryEscape(c.Token), body, "")
+    if err != nil {
+        return false, fmt.Errorf("auth check request failed: %w", err)
+    }
+    defer resp.Body.Close()
+    
+    if resp.StatusCode == http.StatusUnauthorized {
+        return false, nil
+    }
+    
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return false, fmt.Errorf("auth check failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+    
+    // A valid token yields the session's username, an invalid one only
+    // the login URL
+    var checkResp AuthCheckResponse
+    if err := json.NewDecoder(resp.Body).Decode(&checkResp); err != nil {
+        return false, fmt.Errorf("failed to decode auth check response: %w", err)
+    }
+    
+    return checkResp.Username != "", nil
+}
+
+// ensureToken returns a token to send with the next request, logging in if
+// there is none yet and re-validating it via /api/auth/check once it is
+// older than authCheckInterval
+func (c *CephClient) ensureToken(ctx context.Context) (string, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
     if c.Token == "" {
-        if err := c.Authenticate(); err != nil {
-            return err
+        if err := c.authenticate(ctx); err != nil {
+            return "", err
         }
+        return c.Token, nil
     }
     
-    body, _ := json.Marshal(poolReq)
-    req, err := http.NewRequest("POST", c.Endpoint+"/api/pool", bytes.NewBuffer(body))
+    if time.Since(c.tokenCheckedAt) < authCheckInterval {
+        return c.Token, nil
+    }
+    
+    valid, err := c.checkToken(ctx)
     if err != nil {
-        return fmt.Errorf("failed to create pool request: %w", err)
+        return "", err
+    }
+    
+    if !valid {
+        if err := c.authenticate(ctx); err != nil {
+            return "", err
+        }
+        return c.Token, nil
     }
     
-    req.Header.Set("Content-Type", "application/json")
-    req.Header.Set("Authorization", "Bearer "+c.Token)
+    c.tokenCheckedAt = time.Now()
+    return c.Token, nil
+}
+
+// refreshToken logs in again after staleToken was rejected. If another
+// request already replaced staleToken in the meantime, the newer token is
+// reused instead of logging in a second time.
+func (c *CephClient) refreshToken(ctx context.Context, staleToken string) (string, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    if c.Token != "" && c.Token != staleToken {
+        return c.Token, nil
+    }
     
-    resp, err := c.HTTPClient.Do(req)
+    if err := c.authenticate(ctx); err != nil {
+        return "", err
+    }
+    return c.Token, nil
+}
+
+// doRequest sends an authenticated request to the Ceph API. The body, if
+// not nil, is JSON encoded. Idempotent requests are retried according to
+// c.Retry. The caller is responsible for closing the response body.
+func (c *CephClient) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+    return c.doRequestVerified(ctx, method, path, body, nil)
+}
+
+// doRequestVerified is doRequest for requests that are not idempotent. When
+// applied is not nil such requests are retried as well, but before every
+// retry applied is asked whether the previous attempt took effect after
+// all, in which case errAlreadyApplied is returned.
+func (c *CephClient) doRequestVerified(ctx context.Context, method, path string, body interface{}, applied func(context.Context) (bool, error)) (*http.Response, error) {
+    var payload []byte
+    if body != nil {
+        var err error
+        payload, err = json.Marshal(body)
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode request body: %w", err)
+        }
+    }
+    
+    send := func(ctx context.Context) (*http.Response, error) {
+        return c.doAuthenticated(ctx, method, path, payload)
+    }
+    
+    // A failing mgr may have handed over to a standby in the meantime
+    failover := func(ctx context.Context) {
+        if len(c.endpointList()) > 1 {
+            c.failover(ctx, c.activeEndpoint())
+        }
+    }
+    
+    return c.retryRequest(ctx, isIdempotent(method) || applied != nil, send, failover, applied)
+}
+
+// retryRequest calls send until it succeeds, fails permanently or runs out
+// of attempts. Nothing is retried unless retryable. Before every retry
+// failover, if not nil, gets a chance to switch endpoints, and applied, if
+// not nil, is asked whether the previous attempt took effect after all.
+func (c *CephClient) retryRequest(ctx context.Context, retryable bool, send func(context.Context) (*http.Response, error), failover func(context.Context), applied func(context.Context) (bool, error)) (*http.Response, error) {
+    for attempt := 1; ; attempt++ {
+        resp, err := send(ctx)
+        if !retryable || attempt >= c.Retry.MaxAttempts || ctx.Err() != nil || !c.Retry.shouldRetry(resp, err) {
+            return resp, err
+        }
+        
+        wait := c.Retry.backoff(attempt, resp)
+        if resp != nil {
+            resp.Body.Close()
+        }
+        
+        if failover != nil {
+            failover(ctx)
+        }
+        
+        if err := sleepContext(ctx, wait); err != nil {
+            return nil, err
+        }
+        
+        if applied != nil {
+            if ok, err := applied(ctx); err == nil && ok {
+                return nil, errAlreadyApplied
+            }
+        }
+    }
+}
+
+// doAuthenticated sends a single request with the current token. A 401
+// response triggers a single re-authentication after which the request is
+// sent once more.
+func (c *CephClient) doAuthenticated(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+    token, err := c.ensureToken(ctx)
+    if err != nil {
+        return nil, err
+    }
+    
+    resp, err := c.send(ctx, method, path, payload, token)
+    if err != nil {
+        return nil, err
+    }
+    
+    if resp.StatusCode != http.StatusUnauthorized {
+        return resp, nil
+    }
+    resp.Body.Close()
+    
+    token, err = c.refreshToken(ctx, token)
+    if err != nil {
+        return nil, err
+    }
+    
+    return c.send(ctx, method, path, payload, token)
+}
+
+// send performs a single HTTP request against the active endpoint, failing
+// over to another endpoint if the active one is unreachable or redirects to
+// the active mgr. The Authorization header is only set if token is not empty.
+func (c *CephClient) send(ctx context.Context, method, path string, payload []byte, token string) (*http.Response, error) {
+    endpoints := c.endpointList()
+    
+    // Every endpoint gets a chance before giving up, plus one redirect
+    for hop := 0; hop <= len(endpoints); hop++ {
+        endpoint := c.activeEndpoint()
+        
+        resp, err := c.sendTo(ctx, endpoint, method, path, payload, token)
+        if err != nil {
+            // The request never reached the Dashboard, so it is safe to
+            // send it to another mgr
+            if len(endpoints) > 1 && isConnectError(err) && c.failover(ctx, endpoint) {
+                continue
+            }
+            return nil, err
+        }
+        
+        if resp.StatusCode != http.StatusSeeOther {
+            return resp, nil
+        }
+        
+        // Standby mgrs redirect to the active one without handling the request
+        location := resp.Header.Get("Location")
+        resp.Body.Close()
+        
+        active, err := redirectEndpoint(endpoint, location)
+        if err != nil {
+            return nil, err
+        }
+        c.setActiveEndpoint(active)
+    }
+    
+    return nil, fmt.Errorf("no active Ceph Dashboard found among endpoints %v", endpoints)
+}
+
+// sendTo performs a single HTTP request against the given endpoint
+func (c *CephClient) sendTo(ctx context.Context, endpoint, method, path string, payload []byte, token string) (*http.Response, error) {
+    var reqBody io.Reader
+    if payload != nil {
+        reqBody = bytes.NewReader(payload)
+    }
+    
+    req, err := http.NewRequestWithContext(ctx, method, endpoint+path, reqBody)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create request: %w", err)
+    }
+    
+    req.Header.Set("Accept", c.acceptHeader(method, path))
+    if payload != nil {
+        req.Header.Set("Content-Type", "application/json")
+    }
+    if token != "" {
+        req.Header.Set("Authorization", "Bearer "+token)
+    }
+    
+    return c.HTTPClient.Do(req)
+}
+
+// CreatePool creates a new Ceph pool and waits for the creation to finish
+func (c *CephClient) CreatePool(ctx context.Context, poolReq PoolCreateRequest) error {
+    // A retried creation may find the pool created by an earlier attempt
+    // whose response was lost
+    poolExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetPool(ctx, poolReq.Pool)
+        if errors.Is(err, errPoolNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+    
+    resp, err := c.doRequestVerified(ctx, "POST", "/api/pool", poolReq, poolExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
     if err != nil {
         return fmt.Errorf("create pool request failed: %w", err)
     }
     defer resp.Body.Close()
     
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+    
     if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
         bodyBytes, _ := io.ReadAll(resp.Body)
         return fmt.Errorf("failed to create pool with status %d: %s", resp.StatusCode, string(bodyBytes))
@@ -124,28 +723,30 @@ func (c *CephClient) CreatePool(poolReq PoolCreateRequest) error {
 }
 
 // GetPool retrieves information about a pool
-func (c *CephClient) GetPool(poolName string) (map[string]interface{}, error) {
-    if c.Token == "" {
-        if err := c.Authenticate(); err != nil {
-            return nil, err
-        }
-    }
-    
-    req, err := http.NewRequest("GET", c.Endpoint+"/api/pool/"+poolName, nil)
-    if err != nil {
-        return nil, fmt.Errorf("failed to create get pool request: %w", err)
+func (c *CephClient) GetPool(ctx context.Context, poolName string) (*Pool, error) {
+    return c.getPool(ctx, poolName, false)
+}
+
+// GetPoolWithStats retrieves a pool by name along with its usage statistics
+func (c *CephClient) GetPoolWithStats(ctx context.Context, poolName string) (*Pool, error) {
+    return c.getPool(ctx, poolName, true)
+}
+
+// getPool retrieves a pool by name, with its usage statistics if stats is set
+func (c *CephClient) getPool(ctx context.Context, poolName string, stats bool) (*Pool, error) {
+    path := "/api/pool/" + poolName
+    if stats {
+        path += "?stats=true"
     }
     
-    req.Header.Set("Authorization", "Bearer "+c.Token)
-    
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.doRequest(ctx, "GET", path, nil)
     if err != nil {
         return nil, fmt.Errorf("get pool request failed: %w", err)
     }
     defer resp.Body.Close()
     
     if resp.StatusCode == http.StatusNotFound {
-        return nil, fmt.Errorf("pool not found")
+        return nil, errPoolNotFound
     }
     
     if resp.StatusCode != http.StatusOK {
@@ -153,36 +754,86 @@ func (c *CephClient) GetPool(poolName string) (map[string]interface{}, error) {
         return nil, fmt.Errorf("failed to get pool with status %d: %s", resp.StatusCode, string(bodyBytes))
     }
     
-    var pool map[string]interface{}
+    var pool Pool
     if err := json.NewDecoder(resp.Body).Decode(&pool); err != nil {
         return nil, fmt.Errorf("failed to decode pool response: %w", err)
     }
     
-    return pool, nil
+    return &pool, nil
 }
 
-// DeletePool deletes a Ceph pool
-func (c *CephClient) DeletePool(poolName string) error {
-    if c.Token == "" {
-        if err := c.Authenticate(); err != nil {
-            return err
-        }
+// ListPools retrieves all pools, with their usage statistics if stats is set
+func (c *CephClient) ListPools(ctx context.Context, stats bool) ([]Pool, error) {
+    path := "/api/pool"
+    if stats {
+        path += "?stats=true"
     }
     
-    req, err := http.NewRequest("DELETE", c.Endpoint+"/api/pool/"+poolName, nil)
+    resp, err := c.doRequest(ctx, "GET", path, nil)
     if err != nil {
-        return fmt.Errorf("failed to create delete pool request: %w", err)
+        return nil, fmt.Errorf("list pools request failed: %w", err)
     }
+    defer resp.Body.Close()
     
-    req.Header.Set("Authorization", "Bearer "+c.Token)
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to list pools with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+    
+    var pools []Pool
+    if err := json.NewDecoder(resp.Body).Decode(&pools); err != nil {
+        return nil, fmt.Errorf("failed to decode pools response: %w", err)
+    }
     
-    resp, err := c.HTTPClient.Do(req)
+    return pools, nil
+}
+
+// GetPoolByID retrieves a pool by its numeric id, which unlike its name does
+// not change when the pool is renamed
+func (c *CephClient) GetPoolByID(ctx context.Context, id int64) (*Pool, error) {
+    pools, err := c.ListPools(ctx, false)
+    if err != nil {
+        return nil, err
+    }
+    
+    for i := range pools {
+        if pools[i].ID == id {
+            return &pools[i], nil
+        }
+    }
+    
+    return nil, errPoolNotFound
+}
+
+// DeletePool deletes a Ceph pool and waits for the deletion to finish. With
+// ToggleMonAllowPoolDelete the monitors are allowed to delete pools meanwhile.
+func (c *CephClient) DeletePool(ctx context.Context, poolName string) error {
+    if c.ToggleMonAllowPoolDelete {
+        return c.withPoolDeletionAllowed(ctx, func(ctx context.Context) error {
+            return c.deletePool(ctx, poolName)
+        })
+    }
+    return c.deletePool(ctx, poolName)
+}
+
+// deletePool deletes a pool and waits for the deletion to finish
+func (c *CephClient) deletePool(ctx context.Context, poolName string) error {
+    resp, err := c.doRequest(ctx, "DELETE", "/api/pool/"+poolName, nil)
     if err != nil {
         return fmt.Errorf("delete pool request failed: %w", err)
     }
     defer resp.Body.Close()
     
-    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+    
+    // The pool is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+    
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
         bodyBytes, _ := io.ReadAll(resp.Body)
         return fmt.Errorf("failed to delete pool with status %d: %s", resp.StatusCode, string(bodyBytes))
     }
@@ -190,34 +841,54 @@ func (c *CephClient) DeletePool(poolName string) error {
     return nil
 }
 
-// SetPoolProperty sets a property on a pool
-func (c *CephClient) SetPoolProperty(poolName string, property string, value interface{}) error {
-    if c.Token == "" {
-        if err := c.Authenticate(); err != nil {
-            return err
+// SetPoolProperty sets a property on a pool and waits for the change to finish
+func (c *CephClient) SetPoolProperty(ctx context.Context, poolName string, property string, value interface{}) error {
+    return c.SetPoolProperties(ctx, poolName, map[string]interface{}{
+        property: value,
+    })
+}
+
+// SetPoolProperties sets several properties on a pool in a single request
+// and waits for the change to finish
+func (c *CephClient) SetPoolProperties(ctx context.Context, poolName string, properties map[string]interface{}) error {
+    return c.setPoolProperties(ctx, poolName, properties, nil)
+}
+
+// RenamePool renames the pool with the given id and waits for the rename to
+// finish. A retried rename would address the pool by a name it no longer
+// has, so retries first check by id whether an earlier attempt succeeded.
+func (c *CephClient) RenamePool(ctx context.Context, id int64, poolName, newName string) error {
+    renamed := func(ctx context.Context) (bool, error) {
+        pool, err := c.GetPoolByID(ctx, id)
+        if err != nil {
+            return false, err
         }
+        return pool.Name == newName, nil
     }
     
-    requestBody := map[string]interface{}{
-        property: value,
+    err := c.setPoolProperties(ctx, poolName, map[string]interface{}{"pool": newName}, renamed)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
     }
-    
-    body, _ := json.Marshal(requestBody)
-    req, err := http.NewRequest("PATCH", c.Endpoint+"/api/pool/"+poolName, bytes.NewBuffer(body))
-    if err != nil {
-        return fmt.Errorf("failed to create set pool property request: %w", err)
+    return err
+}
+
+// setPoolProperties sends a pool PATCH, verified with applied if not nil
+func (c *CephClient) setPoolProperties(ctx context.Context, poolName string, properties map[string]interface{}, applied func(context.Context) (bool, error)) error {
+    resp, err := c.doRequestVerified(ctx, "PATCH", "/api/pool/"+poolName, properties, applied)
+    if errors.Is(err, errAlreadyApplied) {
+        return err
     }
-    
-    req.Header.Set("Content-Type", "application/json")
-    req.Header.Set("Authorization", "Bearer "+c.Token)
-    
-    resp, err := c.HTTPClient.Do(req)
     if err != nil {
         return fmt.Errorf("set pool property request failed: %w", err)
     }
     defer resp.Body.Close()
     
-    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+    
+    if resp.StatusCode != http.StatusOK {
         bodyBytes, _ := io.ReadAll(resp.Body)
         return fmt.Errorf("failed to set pool property with status %d: %s", resp.StatusCode, string(bodyBytes))
     }
@@ -225,37 +896,129 @@ func (c *CephClient) SetPoolProperty(poolName string, property string, value int
     return nil
 }
 
-// SetApplication sets an application on a pool
-func (c *CephClient) SetApplication(poolName string, application string) error {
-    if c.Token == "" {
-        if err := c.Authenticate(); err != nil {
-            return err
-        }
+// SetApplication enables an application on a pool, keeping the applications
+// already enabled
+func (c *CephClient) SetApplication(ctx context.Context, poolName string, application string) error {
+    pool, err := c.GetPool(ctx, poolName)
+    if err != nil {
+        return err
     }
     
-    requestBody := map[string]interface{}{
-        "application": application,
+    if pool.HasApplication(application) {
+        return nil
     }
     
-    body, _ := json.Marshal(requestBody)
-    req, err := http.NewRequest("POST", c.Endpoint+"/api/pool/"+poolName+"/application", bytes.NewBuffer(body))
-    if err != nil {
-        return fmt.Errorf("failed to create set application request: %w", err)
+    return c.SetApplications(ctx, poolName, append(pool.Applications(), application))
+}
+
+// SetApplications sets the applications enabled on a pool. The Dashboard
+// enables and disables applications to match the given list.
+func (c *CephClient) SetApplications(ctx context.Context, poolName string, applications []string) error {
+    if applications == nil {
+        applications = []string{}
     }
     
-    req.Header.Set("Content-Type", "application/json")
-    req.Header.Set("Authorization", "Bearer "+c.Token)
+    err := c.SetPoolProperty(ctx, poolName, "application_metadata", applications)
+    if err != nil {
+        return fmt.Errorf("set applications failed: %w", err)
+    }
     
-    resp, err := c.HTTPClient.Do(req)
+    return nil
+}
+
+// ListTasks retrieves the executing and finished background tasks with the given name
+func (c *CephClient) ListTasks(ctx context.Context, name string) (*TaskList, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/task?name="+url.QueryEscape(name), nil)
     if err != nil {
-        return fmt.Errorf("set application request failed: %w", err)
+        return nil, fmt.Errorf("list tasks request failed: %w", err)
     }
     defer resp.Body.Close()
     
-    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+    if resp.StatusCode != http.StatusOK {
         bodyBytes, _ := io.ReadAll(resp.Body)
-        return fmt.Errorf("failed to set application with status %d: %s", resp.StatusCode, string(bodyBytes))
+        return nil, fmt.Errorf("failed to list tasks with status %d: %s", resp.StatusCode, string(bodyBytes))
     }
     
-    return nil
-}
\ No newline at end of file
+    var tasks TaskList
+    if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+        return nil, fmt.Errorf("failed to decode task list response: %w", err)
+    }
+    
+    return &tasks, nil
+}
+
+// waitForAcceptedTask decodes the task descriptor of a 202 Accepted
+// response and blocks until that task has finished
+func (c *CephClient) waitForAcceptedTask(ctx context.Context, resp *http.Response) error {
+    var task Task
+    if err := json.NewDecoder(resp.Body).Decode(&task); err != nil && err != io.EOF {
+        return fmt.Errorf("failed to decode task response: %w", err)
+    }
+    
+    // Nothing to track if the Dashboard did not name the task
+    if task.Name == "" {
+        return nil
+    }
+    
+    return c.WaitForTask(ctx, task)
+}
+
+// WaitForTask polls the task list until the task identified by name and
+// metadata is no longer executing. It returns a *TaskError if the task
+// finished with an exception, or the context error if ctx is done first.
+func (c *CephClient) WaitForTask(ctx context.Context, task Task) error {
+    interval := c.TaskPollInterval
+    if interval <= 0 {
+        interval = defaultTaskPollInterval
+    }
+    
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return fmt.Errorf("timed out waiting for task %s: %w", task.Name, ctx.Err())
+        case <-ticker.C:
+        }
+        
+        tasks, err := c.ListTasks(ctx, task.Name)
+        if err != nil {
+            return err
+        }
+        
+        if findTask(tasks.ExecutingTasks, task) != nil {
+            continue
+        }
+        
+        finished := findTask(tasks.FinishedTasks, task)
+        if finished == nil || finished.Success {
+            // The mgr only keeps a bounded history of finished tasks, so a
+            // task that is neither executing nor listed has completed.
+            return nil
+        }
+        
+        taskErr := &TaskError{Name: task.Name}
+        if finished.Exception != nil {
+            taskErr.Detail = finished.Exception.Detail
+        }
+        return taskErr
+    }
+}
+
+// findTask returns the most recent task in tasks matching the name and
+// metadata of target, or nil if there is none
+func findTask(tasks []Task, target Task) *Task {
+    var found *Task
+    for i := range tasks {
+        t := &tasks[i]
+        if t.Name != target.Name || !reflect.DeepEqual(t.Metadata, target.Metadata) {
+            continue
+        }
+        // ISO 8601 timestamps sort lexically
+        if found == nil || t.EndTime > found.EndTime {
+            found = t
+        }
+    }
+    return found
+}
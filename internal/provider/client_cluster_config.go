@@ -0,0 +1,151 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+)
+
+// monAllowPoolDelete is the monitor option that must be true for pools to be
+// deleted
+const monAllowPoolDelete = "mon_allow_pool_delete"
+
+// ClusterConfigOption is a cluster configuration option as returned by the
+// Dashboard API, with the values set in the configuration database per section
+type ClusterConfigOption struct {
+    Name    string               `json:"name"`
+    Default interface{}          `json:"default,omitempty"`
+    Value   []ClusterConfigValue `json:"value,omitempty"`
+}
+
+// ClusterConfigValue is the value of a configuration option in one section,
+// such as "global" or "mon"
+type ClusterConfigValue struct {
+    Section string `json:"section"`
+    Value   string `json:"value"`
+}
+
+// SectionValue returns the value set for the section and whether it is set
+func (o *ClusterConfigOption) SectionValue(section string) (string, bool) {
+    for _, v := range o.Value {
+        if v.Section == section {
+            return v.Value, true
+        }
+    }
+    return "", false
+}
+
+// GetClusterConfig retrieves a cluster configuration option
+func (c *CephClient) GetClusterConfig(ctx context.Context, name string) (*ClusterConfigOption, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/cluster_conf/"+url.PathEscape(name), nil)
+    if err != nil {
+        return nil, fmt.Errorf("get cluster config request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get cluster config %s with status %d: %s", name, resp.StatusCode, string(bodyBytes))
+    }
+
+    var option ClusterConfigOption
+    if err := json.NewDecoder(resp.Body).Decode(&option); err != nil {
+        return nil, fmt.Errorf("failed to decode cluster config response: %w", err)
+    }
+
+    return &option, nil
+}
+
+// SetClusterConfig sets a cluster configuration option for a section
+func (c *CephClient) SetClusterConfig(ctx context.Context, name, section, value string) error {
+    requestBody := map[string]interface{}{
+        "name":  name,
+        "value": []ClusterConfigValue{{Section: section, Value: value}},
+    }
+
+    valueSet := func(ctx context.Context) (bool, error) {
+        option, err := c.GetClusterConfig(ctx, name)
+        if err != nil {
+            return false, err
+        }
+        current, ok := option.SectionValue(section)
+        return ok && current == value, nil
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", "/api/cluster_conf", requestBody, valueSet)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("set cluster config request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to set cluster config %s with status %d: %s", name, resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// DeleteClusterConfig removes a cluster configuration option from a section,
+// restoring its default
+func (c *CephClient) DeleteClusterConfig(ctx context.Context, name, section string) error {
+    path := "/api/cluster_conf/" + url.PathEscape(name) + "?section=" + url.QueryEscape(section)
+    resp, err := c.doRequest(ctx, "DELETE", path, nil)
+    if err != nil {
+        return fmt.Errorf("delete cluster config request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete cluster config %s with status %d: %s", name, resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// withPoolDeletionAllowed runs fn with mon_allow_pool_delete enabled for the
+// monitors and restores the previous setting afterwards. Deletions are
+// serialized so that concurrent ones cannot restore the setting while
+// another is still running.
+func (c *CephClient) withPoolDeletionAllowed(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+    c.poolDeleteMu.Lock()
+    defer c.poolDeleteMu.Unlock()
+
+    option, err := c.GetClusterConfig(ctx, monAllowPoolDelete)
+    if err != nil {
+        return err
+    }
+
+    previous, wasSet := option.SectionValue("mon")
+    if wasSet && previous == "true" {
+        return fn(ctx)
+    }
+
+    if err := c.SetClusterConfig(ctx, monAllowPoolDelete, "mon", "true"); err != nil {
+        return fmt.Errorf("could not enable %s: %w", monAllowPoolDelete, err)
+    }
+
+    // Restore even when the deletion ran into its timeout
+    defer func() {
+        restoreCtx := context.WithoutCancel(ctx)
+        var restoreErr error
+        if wasSet {
+            restoreErr = c.SetClusterConfig(restoreCtx, monAllowPoolDelete, "mon", previous)
+        } else {
+            restoreErr = c.DeleteClusterConfig(restoreCtx, monAllowPoolDelete, "mon")
+        }
+        if restoreErr != nil {
+            err = errors.Join(err, fmt.Errorf("could not restore %s: %w", monAllowPoolDelete, restoreErr))
+        }
+    }()
+
+    return fn(ctx)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+// TestDeletePoolTogglesMonAllowPoolDelete tests that mon_allow_pool_delete is
+// enabled around the deletion and removed again when it was not set before
+func TestDeletePoolTogglesMonAllowPoolDelete(t *testing.T) {
+    var calls []string
+    monValue := ""
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/cluster_conf", func(w http.ResponseWriter, r *http.Request) {
+        var option ClusterConfigOption
+        json.NewDecoder(r.Body).Decode(&option)
+        monValue = option.Value[0].Value
+        calls = append(calls, "set "+monValue)
+        w.WriteHeader(http.StatusCreated)
+    })
+    mux.HandleFunc("/api/cluster_conf/"+monAllowPoolDelete, func(w http.ResponseWriter, r *http.Request) {
+        if r.Method == "DELETE" {
+            monValue = ""
+            calls = append(calls, "unset")
+            w.WriteHeader(http.StatusNoContent)
+            return
+        }
+        option := ClusterConfigOption{Name: monAllowPoolDelete}
+        if monValue != "" {
+            option.Value = []ClusterConfigValue{{Section: "mon", Value: monValue}}
+        }
+        json.NewEncoder(w).Encode(option)
+    })
+    mux.HandleFunc("/api/pool/test", func(w http.ResponseWriter, r *http.Request) {
+        calls = append(calls, "delete with "+monValue)
+        w.WriteHeader(http.StatusNoContent)
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.ToggleMonAllowPoolDelete = true
+    
+    if err := client.DeletePool(context.Background(), "test"); err != nil {
+        t.Fatal(err)
+    }
+    
+    want := "set true, delete with true, unset"
+    if got := strings.Join(calls, ", "); got != want {
+        t.Errorf("Expected calls '%s', got '%s'", want, got)
+    }
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+)
+
+// CRUSH rule types as reported in the CRUSH map
+const (
+    crushRuleTypeReplicated = 1
+    crushRuleTypeErasure    = 3
+)
+
+// errCrushRuleNotFound is returned by GetCrushRule when the rule does not exist
+var errCrushRuleNotFound = errors.New("crush rule not found")
+
+// CrushRule is a CRUSH rule as returned by the Dashboard API
+type CrushRule struct {
+    RuleID   int64           `json:"rule_id"`
+    RuleName string          `json:"rule_name"`
+    Type     int64           `json:"type"`
+    MinSize  int64           `json:"min_size"`
+    MaxSize  int64           `json:"max_size"`
+    Steps    []CrushRuleStep `json:"steps"`
+}
+
+// CrushRuleStep is a single step of a CRUSH rule
+type CrushRuleStep struct {
+    Op       string `json:"op"`
+    Item     int64  `json:"item,omitempty"`
+    ItemName string `json:"item_name,omitempty"`
+    Num      int64  `json:"num,omitempty"`
+    Type     string `json:"type,omitempty"`
+}
+
+// CrushRuleCreateRequest is the structure for creating a replicated CRUSH rule
+type CrushRuleCreateRequest struct {
+    Name          string `json:"name"`
+    Root          string `json:"root"`
+    FailureDomain string `json:"failure_domain"`
+    DeviceClass   string `json:"device_class,omitempty"`
+}
+
+// TypeName returns "replicated" or "erasure"
+func (r *CrushRule) TypeName() string {
+    if r.Type == crushRuleTypeErasure {
+        return poolTypeErasure
+    }
+    return poolTypeReplicated
+}
+
+// Root returns the CRUSH bucket the rule starts from and the device class
+// it is restricted to. Device classes are implemented as shadow trees
+// named "<root>~<class>".
+func (r *CrushRule) Root() (root string, deviceClass string) {
+    for _, step := range r.Steps {
+        if step.Op != "take" {
+            continue
+        }
+        root, deviceClass, _ = strings.Cut(step.ItemName, "~")
+        return root, deviceClass
+    }
+    return "", ""
+}
+
+// FailureDomain returns the bucket type replicas are spread across
+func (r *CrushRule) FailureDomain() string {
+    for _, step := range r.Steps {
+        if strings.HasPrefix(step.Op, "chooseleaf_") || strings.HasPrefix(step.Op, "choose_") {
+            return step.Type
+        }
+    }
+    return ""
+}
+
+// CreateCrushRule creates a new replicated CRUSH rule
+func (c *CephClient) CreateCrushRule(ctx context.Context, ruleReq CrushRuleCreateRequest) error {
+    ruleExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetCrushRule(ctx, ruleReq.Name)
+        if errors.Is(err, errCrushRuleNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", "/api/crush_rule", ruleReq, ruleExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("create crush rule request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to create crush rule with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// GetCrushRule retrieves a CRUSH rule by name
+func (c *CephClient) GetCrushRule(ctx context.Context, name string) (*CrushRule, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/crush_rule/"+url.PathEscape(name), nil)
+    if err != nil {
+        return nil, fmt.Errorf("get crush rule request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errCrushRuleNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get crush rule with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var rule CrushRule
+    if err := json.NewDecoder(resp.Body).Decode(&rule); err != nil {
+        return nil, fmt.Errorf("failed to decode crush rule response: %w", err)
+    }
+
+    // Older releases answer unknown rule names with an empty object
+    if rule.RuleName == "" {
+        return nil, errCrushRuleNotFound
+    }
+
+    return &rule, nil
+}
+
+// ListCrushRules retrieves all CRUSH rules
+func (c *CephClient) ListCrushRules(ctx context.Context) ([]CrushRule, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/crush_rule", nil)
+    if err != nil {
+        return nil, fmt.Errorf("list crush rules request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to list crush rules with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var rules []CrushRule
+    if err := json.NewDecoder(resp.Body).Decode(&rules); err != nil {
+        return nil, fmt.Errorf("failed to decode crush rules response: %w", err)
+    }
+
+    return rules, nil
+}
+
+// DeleteCrushRule deletes a CRUSH rule
+func (c *CephClient) DeleteCrushRule(ctx context.Context, name string) error {
+    resp, err := c.doRequest(ctx, "DELETE", "/api/crush_rule/"+url.PathEscape(name), nil)
+    if err != nil {
+        return fmt.Errorf("delete crush rule request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The rule is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete crush rule with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// deviceClassCrushRuleName returns the name of the replicated CRUSH rule
+// used for pools placed on a device class, following the
+// "replicated_<class>" convention of the Ceph documentation
+func deviceClassCrushRuleName(deviceClass string) string {
+    return "replicated_" + deviceClass
+}
+
+// EnsureDeviceClassCrushRule returns the name of the replicated CRUSH rule
+// for the device class, creating it below the default root with host
+// failure domain if it does not exist yet. An existing rule of that name
+// that does not place data on the device class is rejected rather than
+// changed, as other pools may use it.
+func (c *CephClient) EnsureDeviceClassCrushRule(ctx context.Context, deviceClass string) (string, error) {
+    name := deviceClassCrushRuleName(deviceClass)
+
+    rule, err := c.GetCrushRule(ctx, name)
+    if errors.Is(err, errCrushRuleNotFound) {
+        err = c.CreateCrushRule(ctx, CrushRuleCreateRequest{
+            Name:          name,
+            Root:          defaultCrushRoot,
+            FailureDomain: defaultCrushFailureDomain,
+            DeviceClass:   deviceClass,
+        })
+        if err != nil {
+            return "", err
+        }
+        return name, nil
+    }
+    if err != nil {
+        return "", err
+    }
+
+    if _, class := rule.Root(); rule.Type != crushRuleTypeReplicated || class != deviceClass {
+        return "", fmt.Errorf("crush rule %s exists but is not a replicated rule for device class %s", name, deviceClass)
+    }
+
+    return name, nil
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestCrushRuleSteps tests that root, device class and failure domain are
+// derived from the rule steps
+func TestCrushRuleSteps(t *testing.T) {
+    payload := `{
+        "rule_id": 1,
+        "rule_name": "ssd_rule",
+        "type": 1,
+        "steps": [
+            {"op": "take", "item": -12, "item_name": "default~ssd"},
+            {"op": "chooseleaf_firstn", "num": 0, "type": "rack"},
+            {"op": "emit"}
+        ]
+    }`
+    
+    var rule CrushRule
+    if err := json.Unmarshal([]byte(payload), &rule); err != nil {
+        t.Fatal(err)
+    }
+    
+    root, deviceClass := rule.Root()
+    if root != "default" || deviceClass != "ssd" {
+        t.Errorf("Expected root 'default' and device class 'ssd', got '%s' and '%s'", root, deviceClass)
+    }
+    
+    if fd := rule.FailureDomain(); fd != "rack" {
+        t.Errorf("Expected failure domain 'rack', got '%s'", fd)
+    }
+    
+    if rule.TypeName() != "replicated" {
+        t.Errorf("Expected replicated rule, got '%s'", rule.TypeName())
+    }
+}
+
+// TestCreateCrushRuleVerified tests that a create whose response was lost is
+// not sent again once the rule exists
+func TestCreateCrushRuleVerified(t *testing.T) {
+    posts := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.Method + " " + r.URL.Path {
+        case "POST /api/crush_rule":
+            // The rule is created, but the response is lost
+            posts++
+            w.WriteHeader(http.StatusBadGateway)
+        case "GET /api/crush_rule/ssd_rule":
+            if posts == 0 {
+                w.WriteHeader(http.StatusNotFound)
+                return
+            }
+            w.Write([]byte(`{"rule_id": 2, "rule_name": "ssd_rule", "type": 1}`))
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    err := client.CreateCrushRule(context.Background(), CrushRuleCreateRequest{Name: "ssd_rule", Root: "default", FailureDomain: "host"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if posts != 1 {
+        t.Errorf("Expected the rule to be created once, got %d requests", posts)
+    }
+}
+
+// TestDeleteCrushRule tests that a rule that is already gone counts as
+// deleted and that refusals, e.g. of a rule in use, are reported
+func TestDeleteCrushRule(t *testing.T) {
+    tests := map[string]struct {
+        status  int
+        wantErr bool
+    }{
+        "deleted": {status: http.StatusNoContent},
+        "gone":    {status: http.StatusNotFound},
+        "in use":  {status: http.StatusBadRequest, wantErr: true},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                if r.Method != "DELETE" || r.URL.Path != "/api/crush_rule/ssd_rule" {
+                    t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+                }
+                w.WriteHeader(tt.status)
+            }))
+            defer server.Close()
+            
+            err := newTestRetryClient(server).DeleteCrushRule(context.Background(), "ssd_rule")
+            if (err != nil) != tt.wantErr {
+                t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+            }
+        })
+    }
+}
+
+// TestEnsureDeviceClassCrushRule tests that the rule of a device class is
+// created only if missing and that a same-named rule for another class is
+// rejected
+func TestEnsureDeviceClassCrushRule(t *testing.T) {
+    tests := map[string]struct {
+        existing string
+        created  bool
+        wantErr  bool
+    }{
+        "missing": {created: true},
+        "existing": {existing: `{
+            "rule_id": 2, "rule_name": "replicated_ssd", "type": 1,
+            "steps": [{"op": "take", "item": -12, "item_name": "default~ssd"}, {"op": "chooseleaf_firstn", "type": "host"}, {"op": "emit"}]
+        }`},
+        "other class": {existing: `{
+            "rule_id": 2, "rule_name": "replicated_ssd", "type": 1,
+            "steps": [{"op": "take", "item": -1, "item_name": "default"}, {"op": "chooseleaf_firstn", "type": "host"}, {"op": "emit"}]
+        }`, wantErr: true},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            created := false
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                switch r.Method + " " + r.URL.Path {
+                case "GET /api/crush_rule/replicated_ssd":
+                    if tt.existing == "" {
+                        w.WriteHeader(http.StatusNotFound)
+                        return
+                    }
+                    w.Write([]byte(tt.existing))
+                case "POST /api/crush_rule":
+                    created = true
+                    w.WriteHeader(http.StatusCreated)
+                default:
+                    t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+                    w.WriteHeader(http.StatusNotFound)
+                }
+            }))
+            defer server.Close()
+            
+            rule, err := newTestRetryClient(server).EnsureDeviceClassCrushRule(context.Background(), "ssd")
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("Expected error %t, got %v", tt.wantErr, err)
+            }
+            if !tt.wantErr && rule != "replicated_ssd" {
+                t.Errorf("Expected rule replicated_ssd, got %s", rule)
+            }
+            if created != tt.created {
+                t.Errorf("Expected created %t, got %t", tt.created, created)
+            }
+        })
+    }
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strconv"
+)
+
+// errErasureCodeProfileNotFound is returned by GetErasureCodeProfile when the
+// profile does not exist
+var errErasureCodeProfileNotFound = errors.New("erasure code profile not found")
+
+// ErasureCodeProfile is an erasure code profile as used by the Dashboard API
+type ErasureCodeProfile struct {
+    Name               string    `json:"name"`
+    K                  flexInt64 `json:"k,omitempty"`
+    M                  flexInt64 `json:"m,omitempty"`
+    Plugin             string    `json:"plugin,omitempty"`
+    Technique          string    `json:"technique,omitempty"`
+    CrushFailureDomain string    `json:"crush-failure-domain,omitempty"`
+    CrushDeviceClass   string    `json:"crush-device-class,omitempty"`
+    CrushRoot          string    `json:"crush-root,omitempty"`
+}
+
+// flexInt64 decodes integers that the Ceph API returns either as JSON
+// numbers or as strings, as it does for erasure code profile values
+type flexInt64 int64
+
+// UnmarshalJSON accepts both 4 and "4"
+func (i *flexInt64) UnmarshalJSON(data []byte) error {
+    var s string
+    if err := json.Unmarshal(data, &s); err == nil {
+        n, err := strconv.ParseInt(s, 10, 64)
+        if err != nil {
+            return fmt.Errorf("invalid integer %q: %w", s, err)
+        }
+        *i = flexInt64(n)
+        return nil
+    }
+
+    var n int64
+    if err := json.Unmarshal(data, &n); err != nil {
+        return err
+    }
+    *i = flexInt64(n)
+    return nil
+}
+
+// CreateErasureCodeProfile creates a new erasure code profile
+func (c *CephClient) CreateErasureCodeProfile(ctx context.Context, profile ErasureCodeProfile) error {
+    profileExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetErasureCodeProfile(ctx, profile.Name)
+        if errors.Is(err, errErasureCodeProfileNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", "/api/erasure_code_profile", profile, profileExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("create erasure code profile request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to create erasure code profile with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// GetErasureCodeProfile retrieves an erasure code profile by name
+func (c *CephClient) GetErasureCodeProfile(ctx context.Context, name string) (*ErasureCodeProfile, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/erasure_code_profile/"+url.PathEscape(name), nil)
+    if err != nil {
+        return nil, fmt.Errorf("get erasure code profile request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errErasureCodeProfileNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get erasure code profile with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var profile ErasureCodeProfile
+    if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+        return nil, fmt.Errorf("failed to decode erasure code profile response: %w", err)
+    }
+
+    return &profile, nil
+}
+
+// DeleteErasureCodeProfile deletes an erasure code profile
+func (c *CephClient) DeleteErasureCodeProfile(ctx context.Context, name string) error {
+    resp, err := c.doRequest(ctx, "DELETE", "/api/erasure_code_profile/"+url.PathEscape(name), nil)
+    if err != nil {
+        return fmt.Errorf("delete erasure code profile request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The profile is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete erasure code profile with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+// TestGetErasureCodeProfile tests decoding of profiles whose chunk counts
+// are returned as strings
+func TestGetErasureCodeProfile(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/api/erasure_code_profile/ec42" {
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        w.Write([]byte(`{"name": "ec42", "k": "4", "m": 2, "plugin": "jerasure", "technique": "reed_sol_van", "crush-failure-domain": "host"}`))
+    }))
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.Token = "token"
+    client.tokenCheckedAt = time.Now()
+    
+    profile, err := client.GetErasureCodeProfile(context.Background(), "ec42")
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    if profile.K != 4 || profile.M != 2 || profile.CrushFailureDomain != "host" {
+        t.Errorf("Unexpected profile: %+v", profile)
+    }
+    
+    if _, err := client.GetErasureCodeProfile(context.Background(), "missing"); err != errErasureCodeProfileNotFound {
+        t.Errorf("Expected not found error, got: %v", err)
+    }
+}
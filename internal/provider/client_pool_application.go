@@ -0,0 +1,78 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+)
+
+// GetApplicationMetadata returns the key/value metadata of each application
+// enabled on a pool. The Dashboard only lists the application names, so the
+// metadata is read with `ceph osd pool application get`.
+func (c *CephClient) GetApplicationMetadata(ctx context.Context, pool string) (map[string]map[string]string, error) {
+    out, err := c.MonQuery(ctx, map[string]interface{}{
+        "prefix": "osd pool application get",
+        "pool":   pool,
+        "format": "json",
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    var metadata map[string]map[string]string
+    if err := json.Unmarshal([]byte(out), &metadata); err != nil {
+        return nil, fmt.Errorf("failed to decode application metadata: %w", err)
+    }
+
+    return metadata, nil
+}
+
+// SetApplicationMetadata sets a metadata key of an application enabled on a
+// pool with `ceph osd pool application set`
+func (c *CephClient) SetApplicationMetadata(ctx context.Context, pool, application, key, value string) error {
+    keySet := func(ctx context.Context) (bool, error) {
+        metadata, err := c.GetApplicationMetadata(ctx, pool)
+        if err != nil {
+            return false, err
+        }
+        current, ok := metadata[application][key]
+        return ok && current == value, nil
+    }
+
+    _, err := c.MonCommand(ctx, map[string]interface{}{
+        "prefix": "osd pool application set",
+        "pool":   pool,
+        "app":    application,
+        "key":    key,
+        "value":  value,
+    }, keySet)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
+
+// RemoveApplicationMetadata removes a metadata key of an application enabled
+// on a pool with `ceph osd pool application rm`
+func (c *CephClient) RemoveApplicationMetadata(ctx context.Context, pool, application, key string) error {
+    keyGone := func(ctx context.Context) (bool, error) {
+        metadata, err := c.GetApplicationMetadata(ctx, pool)
+        if err != nil {
+            return false, err
+        }
+        _, ok := metadata[application][key]
+        return !ok, nil
+    }
+
+    _, err := c.MonCommand(ctx, map[string]interface{}{
+        "prefix": "osd pool application rm",
+        "pool":   pool,
+        "app":    application,
+        "key":    key,
+    }, keyGone)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestSetApplicationMetadataVerified tests that a metadata key whose set
+// command response was lost is not set again once the pool has it
+func TestSetApplicationMetadataVerified(t *testing.T) {
+    sets := 0
+    
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var command map[string]interface{}
+        json.NewDecoder(r.Body).Decode(&command)
+        
+        switch command["prefix"] {
+        case "osd pool application set":
+            if command["pool"] != "fs_data" || command["app"] != "cephfs" || command["key"] != "data" || command["value"] != "myfs" {
+                t.Errorf("Unexpected command %v", command)
+            }
+            sets++
+            w.WriteHeader(http.StatusGatewayTimeout)
+        case "osd pool application get":
+            if command["format"] != "json" {
+                t.Errorf("Expected JSON output, got %v", command)
+            }
+            json.NewEncoder(w).Encode(RestfulRequest{
+                IsFinished: true,
+                Finished:   []RestfulCommandResult{{Outb: `{"cephfs":{"data":"myfs"}}`}},
+            })
+        default:
+            t.Errorf("Unexpected command %v", command)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    client.Restful = &RestfulConfig{Endpoint: server.URL, Username: "admin", APIKey: "key"}
+    
+    if err := client.SetApplicationMetadata(context.Background(), "fs_data", "cephfs", "data", "myfs"); err != nil {
+        t.Fatalf("Expected verified metadata update to succeed, got: %s", err)
+    }
+    
+    if sets != 1 {
+        t.Errorf("Expected 1 set command, got %d", sets)
+    }
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+)
+
+// errPoolSnapshotNotFound is returned by GetPoolSnapshot when the snapshot or
+// its pool does not exist
+var errPoolSnapshotNotFound = errors.New("pool snapshot not found")
+
+// poolSnapshotID returns the "pool/snap" ID identifying a pool snapshot
+func poolSnapshotID(pool, snapshot string) string {
+    return pool + "/" + snapshot
+}
+
+// parsePoolSnapshotID splits an ID returned by poolSnapshotID
+func parsePoolSnapshotID(id string) (pool, snapshot string, err error) {
+    pool, snapshot, found := strings.Cut(id, "/")
+    if !found || pool == "" || snapshot == "" || strings.Contains(snapshot, "/") {
+        return "", "", fmt.Errorf("invalid pool snapshot ID %q, expected pool/snapshot", id)
+    }
+    return pool, snapshot, nil
+}
+
+// GetPoolSnapshot retrieves a snapshot from the snapshots listed with its pool
+func (c *CephClient) GetPoolSnapshot(ctx context.Context, poolName, snapshot string) (*PoolSnapshot, error) {
+    pool, err := c.GetPool(ctx, poolName)
+    if errors.Is(err, errPoolNotFound) {
+        return nil, errPoolSnapshotNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    for i := range pool.Snapshots {
+        if pool.Snapshots[i].Name == snapshot {
+            return &pool.Snapshots[i], nil
+        }
+    }
+
+    return nil, errPoolSnapshotNotFound
+}
+
+// CreatePoolSnapshot takes a snapshot of a pool with `ceph osd pool mksnap`.
+// The monitors refuse this for pools in self-managed snapshot mode.
+func (c *CephClient) CreatePoolSnapshot(ctx context.Context, pool, snapshot string) error {
+    snapshotExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetPoolSnapshot(ctx, pool, snapshot)
+        if errors.Is(err, errPoolSnapshotNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    _, err := c.MonCommand(ctx, map[string]interface{}{
+        "prefix": "osd pool mksnap",
+        "pool":   pool,
+        "snap":   snapshot,
+    }, snapshotExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
+
+// DeletePoolSnapshot removes a pool snapshot with `ceph osd pool rmsnap`
+func (c *CephClient) DeletePoolSnapshot(ctx context.Context, pool, snapshot string) error {
+    snapshotGone := func(ctx context.Context) (bool, error) {
+        _, err := c.GetPoolSnapshot(ctx, pool, snapshot)
+        if errors.Is(err, errPoolSnapshotNotFound) {
+            return true, nil
+        }
+        return false, err
+    }
+
+    _, err := c.MonCommand(ctx, map[string]interface{}{
+        "prefix": "osd pool rmsnap",
+        "pool":   pool,
+        "snap":   snapshot,
+    }, snapshotGone)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
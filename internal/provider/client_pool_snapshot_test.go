@@ -0,0 +1,62 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestParsePoolSnapshotID tests splitting pool snapshot IDs
+func TestParsePoolSnapshotID(t *testing.T) {
+    pool, snapshot, err := parsePoolSnapshotID("data/before-migration")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if pool != "data" || snapshot != "before-migration" {
+        t.Errorf("Expected data and before-migration, got %s and %s", pool, snapshot)
+    }
+    
+    for _, id := range []string{"data", "data/", "/snap", "data/a/b"} {
+        if _, _, err := parsePoolSnapshotID(id); err == nil {
+            t.Errorf("%s: expected an error", id)
+        }
+    }
+}
+
+// TestCreatePoolSnapshotVerified tests that a mksnap whose response was lost
+// is not sent again once the snapshot shows up in the pool
+func TestCreatePoolSnapshotVerified(t *testing.T) {
+    mksnaps := 0
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/request", func(w http.ResponseWriter, r *http.Request) {
+        var command map[string]interface{}
+        json.NewDecoder(r.Body).Decode(&command)
+        if command["prefix"] != "osd pool mksnap" || command["pool"] != "data" || command["snap"] != "base" {
+            t.Errorf("Unexpected command %v", command)
+        }
+        mksnaps++
+        w.WriteHeader(http.StatusGatewayTimeout)
+    })
+    mux.HandleFunc("/api/pool/data", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{
+            "pool_name":  "data",
+            "pool_snaps": []PoolSnapshot{{SnapID: 1, Name: "base"}},
+        })
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    client.Restful = &RestfulConfig{Endpoint: server.URL, Username: "admin", APIKey: "key"}
+    
+    if err := client.CreatePoolSnapshot(context.Background(), "data", "base"); err != nil {
+        t.Fatalf("Expected verified snapshot creation to succeed, got: %s", err)
+    }
+    
+    if mksnaps != 1 {
+        t.Errorf("Expected 1 mksnap command, got %d", mksnaps)
+    }
+}
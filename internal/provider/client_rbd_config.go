@@ -0,0 +1,72 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+)
+
+// Levels an RBD configuration option can be set at, as reported in the
+// source of the option
+const (
+    rbdConfigSourceConfig = 0
+    rbdConfigSourcePool   = 1
+    rbdConfigSourceImage  = 2
+)
+
+// RBDConfigOption is an RBD configuration option of a pool or image as
+// returned by the Dashboard API
+type RBDConfigOption struct {
+    Name   string      `json:"name"`
+    Value  json.Number `json:"value"`
+    Source int         `json:"source"`
+}
+
+// rbdConfigOverrides returns the numeric options set at the given level,
+// leaving out those inherited from a higher level
+func rbdConfigOverrides(options []RBDConfigOption, source int) map[string]int64 {
+    overrides := make(map[string]int64)
+    for _, option := range options {
+        if option.Source != source {
+            continue
+        }
+        if v, err := option.Value.Int64(); err == nil {
+            overrides[option.Name] = v
+        }
+    }
+    return overrides
+}
+
+// GetPoolRBDConfiguration retrieves the RBD configuration of a pool
+func (c *CephClient) GetPoolRBDConfiguration(ctx context.Context, pool string) ([]RBDConfigOption, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/pool/"+url.PathEscape(pool)+"/configuration", nil)
+    if err != nil {
+        return nil, fmt.Errorf("get pool rbd configuration request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errPoolNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get pool rbd configuration with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var options []RBDConfigOption
+    if err := json.NewDecoder(resp.Body).Decode(&options); err != nil {
+        return nil, fmt.Errorf("failed to decode pool rbd configuration response: %w", err)
+    }
+
+    return options, nil
+}
+
+// SetPoolRBDConfiguration sets RBD configuration options of a pool. Options
+// set to nil are removed, so that the pool inherits them again.
+func (c *CephClient) SetPoolRBDConfiguration(ctx context.Context, pool string, configuration map[string]interface{}) error {
+    return c.SetPoolProperties(ctx, pool, map[string]interface{}{"configuration": configuration})
+}
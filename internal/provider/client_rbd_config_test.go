@@ -0,0 +1,27 @@
+package provider
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+// TestRBDConfigOverrides tests that only options set at the requested level
+// are returned, whether their values are encoded as numbers or strings
+func TestRBDConfigOverrides(t *testing.T) {
+    payload := `[
+        {"name": "rbd_qos_iops_limit", "value": "1000", "source": 2},
+        {"name": "rbd_qos_bps_limit", "value": 104857600, "source": 2},
+        {"name": "rbd_qos_read_iops_limit", "value": "500", "source": 1},
+        {"name": "rbd_qos_write_iops_limit", "value": "0", "source": 0}
+    ]`
+    
+    var options []RBDConfigOption
+    if err := json.Unmarshal([]byte(payload), &options); err != nil {
+        t.Fatal(err)
+    }
+    
+    overrides := rbdConfigOverrides(options, rbdConfigSourceImage)
+    if len(overrides) != 2 || overrides["rbd_qos_iops_limit"] != 1000 || overrides["rbd_qos_bps_limit"] != 104857600 {
+        t.Errorf("Unexpected image overrides %v", overrides)
+    }
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+)
+
+// errRBDImageNotFound is returned by GetRBDImage when the image does not exist
+var errRBDImageNotFound = errors.New("rbd image not found")
+
+// rbdImplicitFeatures are RBD image features that librbd sets by itself, e.g.
+// from the striping parameters or a data pool, and that cannot be requested
+var rbdImplicitFeatures = map[string]bool{
+    "striping":    true,
+    "data-pool":   true,
+    "operations":  true,
+    "migrating":   true,
+    "non-primary": true,
+}
+
+// rbdFeatures are the RBD image features that can be requested
+var rbdFeatures = []string{"layering", "exclusive-lock", "object-map", "fast-diff", "deep-flatten", "journaling"}
+
+// RBDImage is an RBD image as returned by the Dashboard API
+type RBDImage struct {
+    ID           string   `json:"id"`
+    Name         string   `json:"name"`
+    PoolName     string   `json:"pool_name"`
+    Namespace    string   `json:"namespace"`
+    Size         int64    `json:"size"`
+    ObjSize      int64    `json:"obj_size"`
+    FeaturesName []string `json:"features_name"`
+    StripeUnit   int64    `json:"stripe_unit"`
+    StripeCount  int64    `json:"stripe_count"`
+    DataPool     string   `json:"data_pool"`
+
+    // Parent is the snapshot a clone was created from, nil for images that
+    // are not clones or have been flattened
+    Parent    *RBDImageParent `json:"parent"`
+    Snapshots []RBDSnapshot   `json:"snapshots"`
+
+    // MirrorMode is "snapshot" or "journal" for mirrored images, "Disabled"
+    // otherwise
+    MirrorMode string `json:"mirror_mode"`
+    Primary    bool   `json:"primary"`
+
+    Configuration []RBDConfigOption `json:"configuration"`
+}
+
+// RBDImageParent identifies the parent snapshot of a cloned image
+type RBDImageParent struct {
+    PoolName      string `json:"pool_name"`
+    PoolNamespace string `json:"pool_namespace"`
+    ImageName     string `json:"image_name"`
+    SnapName      string `json:"snap_name"`
+}
+
+// SnapshotSpec returns the "pool/namespace/image@snap" spec of the parent
+func (p *RBDImageParent) SnapshotSpec() string {
+    return rbdSnapshotSpec(rbdImageSpec(p.PoolName, p.PoolNamespace, p.ImageName), p.SnapName)
+}
+
+// RBDImageCreateRequest is the structure for creating an RBD image
+type RBDImageCreateRequest struct {
+    Name        string   `json:"name"`
+    PoolName    string   `json:"pool_name"`
+    Namespace   string   `json:"namespace,omitempty"`
+    Size        int64    `json:"size"`
+    ObjSize     int64    `json:"obj_size,omitempty"`
+    Features    []string `json:"features,omitempty"`
+    StripeUnit  int64    `json:"stripe_unit,omitempty"`
+    StripeCount int64    `json:"stripe_count,omitempty"`
+    DataPool    string   `json:"data_pool,omitempty"`
+
+    Configuration map[string]int64 `json:"configuration,omitempty"`
+}
+
+// Features returns the requestable features enabled on the image, leaving
+// out those librbd manages itself
+func (i *RBDImage) Features() []string {
+    features := make([]string, 0, len(i.FeaturesName))
+    for _, f := range i.FeaturesName {
+        if !rbdImplicitFeatures[f] {
+            features = append(features, f)
+        }
+    }
+    return features
+}
+
+// rbdImageSpec returns the "pool/namespace/image" spec identifying an image,
+// or "pool/image" outside of a namespace
+func rbdImageSpec(pool, namespace, image string) string {
+    if namespace == "" {
+        return pool + "/" + image
+    }
+    return pool + "/" + namespace + "/" + image
+}
+
+// parseRBDImageSpec splits a spec returned by rbdImageSpec into its parts
+func parseRBDImageSpec(spec string) (pool, namespace, image string, err error) {
+    parts := strings.Split(spec, "/")
+    for _, part := range parts {
+        if part == "" {
+            return "", "", "", fmt.Errorf("invalid image spec %q, expected pool/namespace/image or pool/image", spec)
+        }
+    }
+
+    switch len(parts) {
+    case 2:
+        return parts[0], "", parts[1], nil
+    case 3:
+        return parts[0], parts[1], parts[2], nil
+    }
+    return "", "", "", fmt.Errorf("invalid image spec %q, expected pool/namespace/image or pool/image", spec)
+}
+
+// rbdImagePath returns the Dashboard API path of an image. The spec is a
+// single path segment, so its slashes are escaped.
+func rbdImagePath(spec string) string {
+    return "/api/block/image/" + url.PathEscape(spec)
+}
+
+// CreateRBDImage creates a new RBD image and waits for the creation to finish
+func (c *CephClient) CreateRBDImage(ctx context.Context, req RBDImageCreateRequest) error {
+    spec := rbdImageSpec(req.PoolName, req.Namespace, req.Name)
+    imageExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDImage(ctx, spec)
+        if errors.Is(err, errRBDImageNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", "/api/block/image", req, imageExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("create rbd image request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to create rbd image with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// GetRBDImage retrieves an RBD image by its spec
+func (c *CephClient) GetRBDImage(ctx context.Context, spec string) (*RBDImage, error) {
+    resp, err := c.doRequest(ctx, "GET", rbdImagePath(spec), nil)
+    if err != nil {
+        return nil, fmt.Errorf("get rbd image request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errRBDImageNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get rbd image with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var image RBDImage
+    if err := json.NewDecoder(resp.Body).Decode(&image); err != nil {
+        return nil, fmt.Errorf("failed to decode rbd image response: %w", err)
+    }
+
+    return &image, nil
+}
+
+// UpdateRBDImage changes an RBD image, e.g. its size or features, and waits
+// for the change to finish. Renames go through RenameRBDImage.
+func (c *CephClient) UpdateRBDImage(ctx context.Context, spec string, changes map[string]interface{}) error {
+    return c.updateRBDImage(ctx, spec, changes, nil)
+}
+
+// RenameRBDImage renames an RBD image within its pool and namespace and
+// waits for the rename to finish. A retried rename would address the image
+// by a name it no longer has, so retries first check whether an earlier
+// attempt succeeded.
+func (c *CephClient) RenameRBDImage(ctx context.Context, spec, name string) error {
+    pool, namespace, _, err := parseRBDImageSpec(spec)
+    if err != nil {
+        return err
+    }
+    
+    renamed := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDImage(ctx, rbdImageSpec(pool, namespace, name))
+        if errors.Is(err, errRBDImageNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+    
+    err = c.updateRBDImage(ctx, spec, map[string]interface{}{"name": name}, renamed)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
+
+// updateRBDImage sends an image PUT, verified with applied if not nil
+func (c *CephClient) updateRBDImage(ctx context.Context, spec string, changes map[string]interface{}, applied func(context.Context) (bool, error)) error {
+    resp, err := c.doRequestVerified(ctx, "PUT", rbdImagePath(spec), changes, applied)
+    if errors.Is(err, errAlreadyApplied) {
+        return err
+    }
+    if err != nil {
+        return fmt.Errorf("update rbd image request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to update rbd image with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// DeleteRBDImage deletes an RBD image and waits for the deletion to finish
+func (c *CephClient) DeleteRBDImage(ctx context.Context, spec string) error {
+    resp, err := c.doRequest(ctx, "DELETE", rbdImagePath(spec), nil)
+    if err != nil {
+        return fmt.Errorf("delete rbd image request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The image is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete rbd image with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
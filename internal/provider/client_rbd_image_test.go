@@ -0,0 +1,77 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestParseRBDImageSpec tests splitting image specs with and without a namespace
+func TestParseRBDImageSpec(t *testing.T) {
+    tests := map[string][3]string{
+        "rbd/vm-disk":      {"rbd", "", "vm-disk"},
+        "rbd/k8s/pvc-1234": {"rbd", "k8s", "pvc-1234"},
+    }
+    
+    for spec, want := range tests {
+        pool, namespace, image, err := parseRBDImageSpec(spec)
+        if err != nil {
+            t.Errorf("%s: unexpected error: %s", spec, err)
+            continue
+        }
+        if got := [3]string{pool, namespace, image}; got != want {
+            t.Errorf("%s: expected %v, got %v", spec, want, got)
+        }
+        if got := rbdImageSpec(pool, namespace, image); got != spec {
+            t.Errorf("%s: expected round trip, got %s", spec, got)
+        }
+    }
+    
+    for _, spec := range []string{"rbd", "rbd//image", "a/b/c/d"} {
+        if _, _, _, err := parseRBDImageSpec(spec); err == nil {
+            t.Errorf("%s: expected an error", spec)
+        }
+    }
+}
+
+// TestRBDImagePathEscapesSpec tests that the spec is sent as one path segment
+func TestRBDImagePathEscapesSpec(t *testing.T) {
+    if got := rbdImagePath("rbd/k8s/pvc"); got != "/api/block/image/rbd%2Fk8s%2Fpvc" {
+        t.Errorf("Unexpected image path %s", got)
+    }
+}
+
+// TestRenameRBDImageVerified tests that a rename whose response was lost is
+// not sent again under the old name once the image shows up renamed
+func TestRenameRBDImageVerified(t *testing.T) {
+    renames := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch {
+        case r.Method == "PUT" && r.URL.Path == "/api/block/image/rbd/k8s/old":
+            renames++
+            if renames > 1 {
+                w.WriteHeader(http.StatusNotFound)
+                return
+            }
+            // The image was renamed but the response never made it back
+            w.WriteHeader(http.StatusGatewayTimeout)
+        case r.Method == "GET" && r.URL.Path == "/api/block/image/rbd/k8s/new":
+            json.NewEncoder(w).Encode(RBDImage{Name: "new", PoolName: "rbd", Namespace: "k8s"})
+        default:
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    if err := client.RenameRBDImage(context.Background(), "rbd/k8s/old", "new"); err != nil {
+        t.Fatalf("Expected verified image rename to succeed, got: %s", err)
+    }
+    
+    if renames != 1 {
+        t.Errorf("Expected 1 rename request, got %d", renames)
+    }
+}
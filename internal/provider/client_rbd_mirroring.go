@@ -0,0 +1,300 @@
+package provider
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+)
+
+// Pool mirror modes
+const (
+    rbdMirrorModeDisabled = "disabled"
+    rbdMirrorModeImage    = "image"
+    rbdMirrorModePool     = "pool"
+)
+
+// rbdImageMirrorModeSnapshot is the mirror mode of images mirrored with
+// mirror snapshots, as reported with the image
+const rbdImageMirrorModeSnapshot = "snapshot"
+
+// Mirror peer directions
+const (
+    rbdMirrorDirectionRx   = "rx"
+    rbdMirrorDirectionRxTx = "rx-tx"
+)
+
+// errRBDMirrorPeerNotFound is returned by GetRBDMirrorPeer when the peer does
+// not exist
+var errRBDMirrorPeerNotFound = errors.New("rbd mirror peer not found")
+
+// RBDMirrorPeer is a mirror peer of a pool as returned by the Dashboard API
+type RBDMirrorPeer struct {
+    UUID        string `json:"uuid"`
+    ClusterName string `json:"cluster_name"`
+    ClientID    string `json:"client_id"`
+    MonHost     string `json:"mon_host"`
+}
+
+// RBDMirrorBootstrapToken is the content of a peer bootstrap token, which
+// carries the credentials for the remote cluster
+type RBDMirrorBootstrapToken struct {
+    FSID     string `json:"fsid"`
+    ClientID string `json:"client_id"`
+    Key      string `json:"key"`
+    MonHost  string `json:"mon_host"`
+}
+
+// parseRBDMirrorBootstrapToken decodes a base64 encoded bootstrap token
+func parseRBDMirrorBootstrapToken(token string) (*RBDMirrorBootstrapToken, error) {
+    data, err := base64.StdEncoding.DecodeString(token)
+    if err != nil {
+        return nil, fmt.Errorf("invalid bootstrap token: %w", err)
+    }
+
+    var t RBDMirrorBootstrapToken
+    if err := json.Unmarshal(data, &t); err != nil {
+        return nil, fmt.Errorf("invalid bootstrap token: %w", err)
+    }
+    if t.MonHost == "" || t.ClientID == "" {
+        return nil, errors.New("invalid bootstrap token: missing mon_host or client_id")
+    }
+
+    return &t, nil
+}
+
+// rbdMirrorPoolPath returns the Dashboard API path of the mirroring settings
+// of a pool
+func rbdMirrorPoolPath(pool string) string {
+    return "/api/block/mirroring/pool/" + url.PathEscape(pool)
+}
+
+// GetRBDMirrorPoolMode retrieves the mirror mode of a pool
+func (c *CephClient) GetRBDMirrorPoolMode(ctx context.Context, pool string) (string, error) {
+    resp, err := c.doRequest(ctx, "GET", rbdMirrorPoolPath(pool), nil)
+    if err != nil {
+        return "", fmt.Errorf("get rbd mirror pool request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return "", errPoolNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return "", fmt.Errorf("failed to get rbd mirror pool with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var result struct {
+        MirrorMode string `json:"mirror_mode"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return "", fmt.Errorf("failed to decode rbd mirror pool response: %w", err)
+    }
+
+    return result.MirrorMode, nil
+}
+
+// SetRBDMirrorPoolMode sets the mirror mode of a pool and waits for the
+// change to finish
+func (c *CephClient) SetRBDMirrorPoolMode(ctx context.Context, pool, mode string) error {
+    requestBody := map[string]interface{}{
+        "mirror_mode": mode,
+    }
+
+    resp, err := c.doRequest(ctx, "PUT", rbdMirrorPoolPath(pool), requestBody)
+    if err != nil {
+        return fmt.Errorf("set rbd mirror pool mode request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to set rbd mirror pool mode with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// CreateRBDMirrorBootstrapToken creates a token that lets another cluster add
+// this one as mirror peer of the pool
+func (c *CephClient) CreateRBDMirrorBootstrapToken(ctx context.Context, pool string) (string, error) {
+    resp, err := c.doRequest(ctx, "POST", rbdMirrorPoolPath(pool)+"/bootstrap/token", nil)
+    if err != nil {
+        return "", fmt.Errorf("create rbd mirror bootstrap token request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return "", fmt.Errorf("failed to create rbd mirror bootstrap token with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var result struct {
+        Token string `json:"token"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return "", fmt.Errorf("failed to decode rbd mirror bootstrap token response: %w", err)
+    }
+
+    return result.Token, nil
+}
+
+// ImportRBDMirrorBootstrapToken adds the cluster the token was created on as
+// mirror peer of the pool and waits for the import to finish
+func (c *CephClient) ImportRBDMirrorBootstrapToken(ctx context.Context, pool, direction, token string) error {
+    requestBody := map[string]interface{}{
+        "direction": direction,
+        "token":     token,
+    }
+
+    resp, err := c.doRequest(ctx, "POST", rbdMirrorPoolPath(pool)+"/bootstrap/peer", requestBody)
+    if err != nil {
+        return fmt.Errorf("import rbd mirror bootstrap token request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to import rbd mirror bootstrap token with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// ListRBDMirrorPeers retrieves the uuids of the mirror peers of a pool
+func (c *CephClient) ListRBDMirrorPeers(ctx context.Context, pool string) ([]string, error) {
+    resp, err := c.doRequest(ctx, "GET", rbdMirrorPoolPath(pool)+"/peer", nil)
+    if err != nil {
+        return nil, fmt.Errorf("list rbd mirror peers request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to list rbd mirror peers with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var uuids []string
+    if err := json.NewDecoder(resp.Body).Decode(&uuids); err != nil {
+        return nil, fmt.Errorf("failed to decode rbd mirror peers response: %w", err)
+    }
+
+    return uuids, nil
+}
+
+// GetRBDMirrorPeer retrieves a mirror peer of a pool by uuid
+func (c *CephClient) GetRBDMirrorPeer(ctx context.Context, pool, uuid string) (*RBDMirrorPeer, error) {
+    resp, err := c.doRequest(ctx, "GET", rbdMirrorPoolPath(pool)+"/peer/"+url.PathEscape(uuid), nil)
+    if err != nil {
+        return nil, fmt.Errorf("get rbd mirror peer request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errRBDMirrorPeerNotFound
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to get rbd mirror peer with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var peer RBDMirrorPeer
+    if err := json.NewDecoder(resp.Body).Decode(&peer); err != nil {
+        return nil, fmt.Errorf("failed to decode rbd mirror peer response: %w", err)
+    }
+
+    return &peer, nil
+}
+
+// FindRBDMirrorPeer retrieves the mirror peer of a pool that connects to the
+// cluster a bootstrap token was created on
+func (c *CephClient) FindRBDMirrorPeer(ctx context.Context, pool string, token *RBDMirrorBootstrapToken) (*RBDMirrorPeer, error) {
+    uuids, err := c.ListRBDMirrorPeers(ctx, pool)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, uuid := range uuids {
+        peer, err := c.GetRBDMirrorPeer(ctx, pool, uuid)
+        if errors.Is(err, errRBDMirrorPeerNotFound) {
+            continue
+        }
+        if err != nil {
+            return nil, err
+        }
+        if peer.MonHost == token.MonHost && peer.ClientID == token.ClientID {
+            return peer, nil
+        }
+    }
+
+    return nil, errRBDMirrorPeerNotFound
+}
+
+// DeleteRBDMirrorPeer removes a mirror peer from a pool
+func (c *CephClient) DeleteRBDMirrorPeer(ctx context.Context, pool, uuid string) error {
+    resp, err := c.doRequest(ctx, "DELETE", rbdMirrorPoolPath(pool)+"/peer/"+url.PathEscape(uuid), nil)
+    if err != nil {
+        return fmt.Errorf("delete rbd mirror peer request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The peer is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete rbd mirror peer with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// EnableRBDImageMirroring enables snapshot-based mirroring of an image, with
+// mirror snapshots taken at the interval if one is given
+func (c *CephClient) EnableRBDImageMirroring(ctx context.Context, spec, interval string) error {
+    changes := map[string]interface{}{
+        "enable_mirror": true,
+        "mirror_mode":   rbdImageMirrorModeSnapshot,
+    }
+    if interval != "" {
+        changes["schedule_interval"] = interval
+    }
+    return c.UpdateRBDImage(ctx, spec, changes)
+}
+
+// SetRBDImageMirrorSchedule replaces the mirror snapshot schedule of an image,
+// removing it when the interval is empty. Adding a schedule keeps existing
+// ones, so they are removed first.
+func (c *CephClient) SetRBDImageMirrorSchedule(ctx context.Context, spec, interval string) error {
+    err := c.UpdateRBDImage(ctx, spec, map[string]interface{}{"remove_scheduling": true})
+    if err != nil || interval == "" {
+        return err
+    }
+    return c.UpdateRBDImage(ctx, spec, map[string]interface{}{"schedule_interval": interval})
+}
+
+// DisableRBDImageMirroring disables mirroring of an image
+func (c *CephClient) DisableRBDImageMirroring(ctx context.Context, spec string) error {
+    return c.UpdateRBDImage(ctx, spec, map[string]interface{}{"enable_mirror": false})
+}
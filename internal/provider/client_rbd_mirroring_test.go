@@ -0,0 +1,60 @@
+package provider
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestParseRBDMirrorBootstrapToken tests decoding tokens and rejecting
+// malformed ones
+func TestParseRBDMirrorBootstrapToken(t *testing.T) {
+    payload := `{"fsid": "f0e1", "client_id": "rbd-mirror-peer", "key": "AQB=", "mon_host": "[v2:10.0.0.1:3300/0]"}`
+    token, err := parseRBDMirrorBootstrapToken(base64.StdEncoding.EncodeToString([]byte(payload)))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if token.ClientID != "rbd-mirror-peer" || token.MonHost != "[v2:10.0.0.1:3300/0]" {
+        t.Errorf("Unexpected token %+v", token)
+    }
+    
+    for _, invalid := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("{}"))} {
+        if _, err := parseRBDMirrorBootstrapToken(invalid); err == nil {
+            t.Errorf("%s: expected an error", invalid)
+        }
+    }
+}
+
+// TestFindRBDMirrorPeer tests that the peer is matched by monitors and user
+func TestFindRBDMirrorPeer(t *testing.T) {
+    peers := map[string]RBDMirrorPeer{
+        "a1": {UUID: "a1", ClientID: "rbd-mirror-peer", MonHost: "10.0.0.1"},
+        "b2": {UUID: "b2", ClientID: "rbd-mirror-peer", MonHost: "10.0.1.1"},
+    }
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/block/mirroring/pool/rbd/peer", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode([]string{"a1", "b2"})
+    })
+    mux.HandleFunc("/api/block/mirroring/pool/rbd/peer/", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(peers[r.URL.Path[len("/api/block/mirroring/pool/rbd/peer/"):]])
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    
+    peer, err := client.FindRBDMirrorPeer(context.Background(), "rbd", &RBDMirrorBootstrapToken{ClientID: "rbd-mirror-peer", MonHost: "10.0.1.1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if peer.UUID != "b2" {
+        t.Errorf("Expected peer b2, got %s", peer.UUID)
+    }
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+)
+
+// errRBDNamespaceNotFound is returned by GetRBDNamespace when the namespace
+// or its pool does not exist
+var errRBDNamespaceNotFound = errors.New("rbd namespace not found")
+
+// RBDNamespace is an RBD namespace as listed by the Dashboard API
+type RBDNamespace struct {
+    Namespace string `json:"namespace"`
+    NumImages int64  `json:"num_images"`
+}
+
+// rbdNamespacesPath returns the Dashboard API path of the namespaces of a pool
+func rbdNamespacesPath(pool string) string {
+    return "/api/block/pool/" + url.PathEscape(pool) + "/namespace"
+}
+
+// ListRBDNamespaces retrieves the RBD namespaces of a pool
+func (c *CephClient) ListRBDNamespaces(ctx context.Context, pool string) ([]RBDNamespace, error) {
+    resp, err := c.doRequest(ctx, "GET", rbdNamespacesPath(pool), nil)
+    if err != nil {
+        return nil, fmt.Errorf("list rbd namespaces request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return nil, fmt.Errorf("failed to list rbd namespaces with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var namespaces []RBDNamespace
+    if err := json.NewDecoder(resp.Body).Decode(&namespaces); err != nil {
+        return nil, fmt.Errorf("failed to decode rbd namespaces response: %w", err)
+    }
+
+    return namespaces, nil
+}
+
+// GetRBDNamespace retrieves an RBD namespace from the namespaces of its pool
+func (c *CephClient) GetRBDNamespace(ctx context.Context, pool, namespace string) (*RBDNamespace, error) {
+    // The pool must exist for the namespace to exist
+    if _, err := c.GetPool(ctx, pool); err != nil {
+        if errors.Is(err, errPoolNotFound) {
+            return nil, errRBDNamespaceNotFound
+        }
+        return nil, err
+    }
+
+    namespaces, err := c.ListRBDNamespaces(ctx, pool)
+    if err != nil {
+        return nil, err
+    }
+
+    for i := range namespaces {
+        if namespaces[i].Namespace == namespace {
+            return &namespaces[i], nil
+        }
+    }
+
+    return nil, errRBDNamespaceNotFound
+}
+
+// CreateRBDNamespace creates an RBD namespace in a pool
+func (c *CephClient) CreateRBDNamespace(ctx context.Context, pool, namespace string) error {
+    requestBody := map[string]interface{}{
+        "namespace": namespace,
+    }
+
+    namespaceExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDNamespace(ctx, pool, namespace)
+        if errors.Is(err, errRBDNamespaceNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", rbdNamespacesPath(pool), requestBody, namespaceExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("create rbd namespace request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to create rbd namespace with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// DeleteRBDNamespace deletes an RBD namespace, which must not contain images
+func (c *CephClient) DeleteRBDNamespace(ctx context.Context, pool, namespace string) error {
+    resp, err := c.doRequest(ctx, "DELETE", rbdNamespacesPath(pool)+"/"+url.PathEscape(namespace), nil)
+    if err != nil {
+        return fmt.Errorf("delete rbd namespace request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The namespace is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete rbd namespace with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestGetRBDNamespace tests that namespaces are found in the pool's listing
+// and that a missing pool reads as a missing namespace
+func TestGetRBDNamespace(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/pool/rbd", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(Pool{Name: "rbd"})
+    })
+    mux.HandleFunc("/api/pool/missing", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+    })
+    mux.HandleFunc("/api/block/pool/rbd/namespace", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode([]RBDNamespace{
+            {Namespace: "k8s", NumImages: 3},
+            {Namespace: "vms", NumImages: 0},
+        })
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    ctx := context.Background()
+    
+    ns, err := client.GetRBDNamespace(ctx, "rbd", "k8s")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if ns.NumImages != 3 {
+        t.Errorf("Expected 3 images, got %d", ns.NumImages)
+    }
+    
+    if _, err := client.GetRBDNamespace(ctx, "rbd", "other"); !errors.Is(err, errRBDNamespaceNotFound) {
+        t.Errorf("Expected errRBDNamespaceNotFound for a missing namespace, got %v", err)
+    }
+    
+    if _, err := client.GetRBDNamespace(ctx, "missing", "k8s"); !errors.Is(err, errRBDNamespaceNotFound) {
+        t.Errorf("Expected errRBDNamespaceNotFound for a missing pool, got %v", err)
+    }
+}
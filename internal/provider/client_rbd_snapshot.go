@@ -0,0 +1,286 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+)
+
+// errRBDSnapshotNotFound is returned by GetRBDSnapshot when the snapshot or
+// its image does not exist
+var errRBDSnapshotNotFound = errors.New("rbd snapshot not found")
+
+// RBDSnapshot is a snapshot of an RBD image as listed by the Dashboard API
+type RBDSnapshot struct {
+    ID          int64         `json:"id"`
+    Name        string        `json:"name"`
+    Size        int64         `json:"size"`
+    Timestamp   string        `json:"timestamp"`
+    IsProtected bool          `json:"is_protected"`
+    Children    []RBDImageRef `json:"children"`
+}
+
+// RBDImageRef identifies an image, e.g. a clone of a snapshot
+type RBDImageRef struct {
+    PoolName      string `json:"pool_name"`
+    PoolNamespace string `json:"pool_namespace"`
+    ImageName     string `json:"image_name"`
+}
+
+// Spec returns the image spec of the referenced image
+func (r RBDImageRef) Spec() string {
+    return rbdImageSpec(r.PoolName, r.PoolNamespace, r.ImageName)
+}
+
+// ChildSpecs returns the image specs of the clones of the snapshot
+func (s *RBDSnapshot) ChildSpecs() []string {
+    specs := make([]string, 0, len(s.Children))
+    for _, child := range s.Children {
+        specs = append(specs, child.Spec())
+    }
+    return specs
+}
+
+// RBDCloneRequest is the structure for cloning an RBD snapshot
+type RBDCloneRequest struct {
+    ChildPoolName  string   `json:"child_pool_name"`
+    ChildImageName string   `json:"child_image_name"`
+    ChildNamespace string   `json:"child_namespace,omitempty"`
+    ObjSize        int64    `json:"obj_size,omitempty"`
+    Features       []string `json:"features,omitempty"`
+    StripeUnit     int64    `json:"stripe_unit,omitempty"`
+    StripeCount    int64    `json:"stripe_count,omitempty"`
+    DataPool       string   `json:"data_pool,omitempty"`
+}
+
+// rbdSnapshotSpec returns the "pool/namespace/image@snap" spec identifying a
+// snapshot
+func rbdSnapshotSpec(imageSpec, snapshot string) string {
+    return imageSpec + "@" + snapshot
+}
+
+// parseRBDSnapshotSpec splits a spec returned by rbdSnapshotSpec into the
+// image spec and the snapshot name
+func parseRBDSnapshotSpec(spec string) (imageSpec, snapshot string, err error) {
+    imageSpec, snapshot, found := strings.Cut(spec, "@")
+    if !found || snapshot == "" {
+        return "", "", fmt.Errorf("invalid snapshot spec %q, expected pool/namespace/image@snapshot or pool/image@snapshot", spec)
+    }
+    if _, _, _, err := parseRBDImageSpec(imageSpec); err != nil {
+        return "", "", fmt.Errorf("invalid snapshot spec %q: %w", spec, err)
+    }
+    return imageSpec, snapshot, nil
+}
+
+// rbdSnapshotPath returns the Dashboard API path of an image snapshot
+func rbdSnapshotPath(imageSpec, snapshot string) string {
+    return rbdImagePath(imageSpec) + "/snap/" + url.PathEscape(snapshot)
+}
+
+// CreateRBDSnapshot creates a snapshot of an RBD image and waits for the
+// creation to finish
+func (c *CephClient) CreateRBDSnapshot(ctx context.Context, imageSpec, snapshot string) error {
+    requestBody := map[string]interface{}{
+        "snapshot_name": snapshot,
+    }
+
+    snapshotExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDSnapshot(ctx, imageSpec, snapshot)
+        if errors.Is(err, errRBDSnapshotNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", rbdImagePath(imageSpec)+"/snap", requestBody, snapshotExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("create rbd snapshot request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to create rbd snapshot with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// GetRBDSnapshot retrieves a snapshot from the snapshots listed with its image
+func (c *CephClient) GetRBDSnapshot(ctx context.Context, imageSpec, snapshot string) (*RBDSnapshot, error) {
+    image, err := c.GetRBDImage(ctx, imageSpec)
+    if errors.Is(err, errRBDImageNotFound) {
+        return nil, errRBDSnapshotNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    for i := range image.Snapshots {
+        if image.Snapshots[i].Name == snapshot {
+            return &image.Snapshots[i], nil
+        }
+    }
+
+    return nil, errRBDSnapshotNotFound
+}
+
+// UpdateRBDSnapshot (un)protects a snapshot and waits for the change to
+// finish. Renames go through RenameRBDSnapshot.
+func (c *CephClient) UpdateRBDSnapshot(ctx context.Context, imageSpec, snapshot string, changes map[string]interface{}) error {
+    return c.updateRBDSnapshot(ctx, imageSpec, snapshot, changes, nil)
+}
+
+// RenameRBDSnapshot renames a snapshot and waits for the rename to finish. A
+// retried rename would address the snapshot by a name it no longer has, so
+// retries first check whether an earlier attempt succeeded.
+func (c *CephClient) RenameRBDSnapshot(ctx context.Context, imageSpec, snapshot, name string) error {
+    renamed := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDSnapshot(ctx, imageSpec, name)
+        if errors.Is(err, errRBDSnapshotNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    err := c.updateRBDSnapshot(ctx, imageSpec, snapshot, map[string]interface{}{"new_snap_name": name}, renamed)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    return err
+}
+
+// updateRBDSnapshot sends a snapshot PUT, verified with applied if not nil
+func (c *CephClient) updateRBDSnapshot(ctx context.Context, imageSpec, snapshot string, changes map[string]interface{}, applied func(context.Context) (bool, error)) error {
+    resp, err := c.doRequestVerified(ctx, "PUT", rbdSnapshotPath(imageSpec, snapshot), changes, applied)
+    if errors.Is(err, errAlreadyApplied) {
+        return err
+    }
+    if err != nil {
+        return fmt.Errorf("update rbd snapshot request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to update rbd snapshot with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// DeleteRBDSnapshot deletes a snapshot and waits for the deletion to finish.
+// The snapshot must not be protected.
+func (c *CephClient) DeleteRBDSnapshot(ctx context.Context, imageSpec, snapshot string) error {
+    resp, err := c.doRequest(ctx, "DELETE", rbdSnapshotPath(imageSpec, snapshot), nil)
+    if err != nil {
+        return fmt.Errorf("delete rbd snapshot request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    // The snapshot is already gone, e.g. removed by a retried attempt
+    if resp.StatusCode == http.StatusNotFound {
+        return nil
+    }
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to delete rbd snapshot with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// RollbackRBDSnapshot rolls the image back to the snapshot, discarding the
+// data written to it since, and waits for the rollback to finish
+func (c *CephClient) RollbackRBDSnapshot(ctx context.Context, imageSpec, snapshot string) error {
+    resp, err := c.doRequest(ctx, "POST", rbdSnapshotPath(imageSpec, snapshot)+"/rollback", nil)
+    if err != nil {
+        return fmt.Errorf("rollback rbd snapshot request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to rollback rbd snapshot with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// CloneRBDSnapshot creates a clone of a snapshot and waits for the clone to
+// be created
+func (c *CephClient) CloneRBDSnapshot(ctx context.Context, imageSpec, snapshot string, req RBDCloneRequest) error {
+    childSpec := rbdImageSpec(req.ChildPoolName, req.ChildNamespace, req.ChildImageName)
+    cloneExists := func(ctx context.Context) (bool, error) {
+        _, err := c.GetRBDImage(ctx, childSpec)
+        if errors.Is(err, errRBDImageNotFound) {
+            return false, nil
+        }
+        return err == nil, err
+    }
+
+    resp, err := c.doRequestVerified(ctx, "POST", rbdSnapshotPath(imageSpec, snapshot)+"/clone", req, cloneExists)
+    if errors.Is(err, errAlreadyApplied) {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("clone rbd snapshot request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to clone rbd snapshot with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
+
+// FlattenRBDImage copies the parent data into a clone, detaching it from its
+// parent snapshot, and waits for the copy to finish
+func (c *CephClient) FlattenRBDImage(ctx context.Context, spec string) error {
+    resp, err := c.doRequest(ctx, "POST", rbdImagePath(spec)+"/flatten", nil)
+    if err != nil {
+        return fmt.Errorf("flatten rbd image request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusAccepted {
+        return c.waitForAcceptedTask(ctx, resp)
+    }
+
+    if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return fmt.Errorf("failed to flatten rbd image with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    return nil
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+// TestParseRBDSnapshotSpec tests splitting snapshot specs into image spec and name
+func TestParseRBDSnapshotSpec(t *testing.T) {
+    tests := map[string][2]string{
+        "rbd/golden@base":         {"rbd/golden", "base"},
+        "rbd/k8s/pvc-1234@backup": {"rbd/k8s/pvc-1234", "backup"},
+    }
+    
+    for spec, want := range tests {
+        imageSpec, snapshot, err := parseRBDSnapshotSpec(spec)
+        if err != nil {
+            t.Errorf("%s: unexpected error: %s", spec, err)
+            continue
+        }
+        if got := [2]string{imageSpec, snapshot}; got != want {
+            t.Errorf("%s: expected %v, got %v", spec, want, got)
+        }
+        if got := rbdSnapshotSpec(imageSpec, snapshot); got != spec {
+            t.Errorf("%s: expected round trip, got %s", spec, got)
+        }
+    }
+    
+    for _, spec := range []string{"rbd/golden", "rbd/golden@", "rbd@base", "rbd//golden@base"} {
+        if _, _, err := parseRBDSnapshotSpec(spec); err == nil {
+            t.Errorf("%s: expected an error", spec)
+        }
+    }
+}
+
+// TestRBDSnapshotPathEscapesNames tests that image spec and snapshot name are
+// sent as single path segments
+func TestRBDSnapshotPathEscapesNames(t *testing.T) {
+    if got := rbdSnapshotPath("rbd/k8s/pvc", "daily 1"); got != "/api/block/image/rbd%2Fk8s%2Fpvc/snap/daily%201" {
+        t.Errorf("Unexpected snapshot path %s", got)
+    }
+}
+
+// TestRenameRBDSnapshotVerified tests that a rename whose response was lost
+// is not sent again under the old name once the snapshot shows up renamed
+func TestRenameRBDSnapshotVerified(t *testing.T) {
+    renames := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch {
+        case r.Method == "PUT" && r.URL.Path == "/api/block/image/rbd/golden/snap/old":
+            renames++
+            if renames > 1 {
+                w.WriteHeader(http.StatusNotFound)
+                return
+            }
+            // The snapshot was renamed but the response never made it back
+            w.WriteHeader(http.StatusGatewayTimeout)
+        case r.Method == "GET" && r.URL.Path == "/api/block/image/rbd/golden":
+            json.NewEncoder(w).Encode(RBDImage{Name: "golden", PoolName: "rbd", Snapshots: []RBDSnapshot{{ID: 4, Name: "new"}}})
+        default:
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    if err := client.RenameRBDSnapshot(context.Background(), "rbd/golden", "old", "new"); err != nil {
+        t.Fatalf("Expected verified snapshot rename to succeed, got: %s", err)
+    }
+    
+    if renames != 1 {
+        t.Errorf("Expected 1 rename request, got %d", renames)
+    }
+}
+
+// TestRollbackRBDSnapshot tests that a rollback is posted to the snapshot and
+// waits for the background task the Dashboard starts for it
+func TestRollbackRBDSnapshot(t *testing.T) {
+    task := Task{Name: "rbd/snap/rollback", Metadata: map[string]interface{}{"image_spec": "rbd/golden", "snapshot_name": "base"}}
+    rollbacks, polls := 0, 0
+    
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/api/block/image/rbd/golden/snap/base/rollback":
+            if r.Method != "POST" {
+                t.Errorf("Expected POST, got %s", r.Method)
+            }
+            rollbacks++
+            w.WriteHeader(http.StatusAccepted)
+            json.NewEncoder(w).Encode(task)
+        case "/api/task":
+            var tasks TaskList
+            polls++
+            if polls == 1 {
+                tasks.ExecutingTasks = []Task{task}
+            } else {
+                finished := task
+                finished.Success = true
+                tasks.FinishedTasks = []Task{finished}
+            }
+            json.NewEncoder(w).Encode(tasks)
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    client.TaskPollInterval = time.Millisecond
+    
+    if err := client.RollbackRBDSnapshot(context.Background(), "rbd/golden", "base"); err != nil {
+        t.Fatalf("Expected rollback to succeed, got: %s", err)
+    }
+    
+    if rollbacks != 1 {
+        t.Errorf("Expected 1 rollback request, got %d", rollbacks)
+    }
+    if polls != 2 {
+        t.Errorf("Expected the rollback task to be polled until finished, got %d polls", polls)
+    }
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+)
+
+// errRestfulNotConfigured is returned by MonCommand when the provider has no
+// restful configuration
+var errRestfulNotConfigured = errors.New("the mgr restful module is not configured, set the restful block of the provider")
+
+// RestfulConfig describes how to reach the mgr restful module. It is
+// authenticated with an API key created by `ceph restful create-key`.
+type RestfulConfig struct {
+    Endpoint string
+    Username string
+    APIKey   string
+}
+
+// RestfulRequest is a command request as reported by the restful module
+type RestfulRequest struct {
+    ID         string                 `json:"id"`
+    State      string                 `json:"state"`
+    IsFinished bool                   `json:"is_finished"`
+    HasFailed  bool                   `json:"has_failed"`
+    Finished   []RestfulCommandResult `json:"finished"`
+    Failed     []RestfulCommandResult `json:"failed"`
+}
+
+// RestfulCommandResult is the outcome of a single command of a request
+type RestfulCommandResult struct {
+    Command string `json:"command"`
+    Outb    string `json:"outb"`
+    Outs    string `json:"outs"`
+}
+
+// MonCommand runs a mon command such as {"prefix": "osd pool mksnap", ...}
+// through the restful module, waits for it to finish and returns its
+// output. Commands are retried like doRequestVerified, i.e. only when
+// applied is not nil.
+func (c *CephClient) MonCommand(ctx context.Context, command map[string]interface{}, applied func(context.Context) (bool, error)) (string, error) {
+    return c.monCommand(ctx, command, applied != nil, applied)
+}
+
+// MonQuery runs a read-only mon command, such as one of the get commands,
+// which is retried like any idempotent request
+func (c *CephClient) MonQuery(ctx context.Context, command map[string]interface{}) (string, error) {
+    return c.monCommand(ctx, command, true, nil)
+}
+
+// monCommand runs a mon command, retrying it if retryable
+func (c *CephClient) monCommand(ctx context.Context, command map[string]interface{}, retryable bool, applied func(context.Context) (bool, error)) (string, error) {
+    if c.Restful == nil {
+        return "", errRestfulNotConfigured
+    }
+
+    payload, err := json.Marshal(command)
+    if err != nil {
+        return "", fmt.Errorf("failed to encode command: %w", err)
+    }
+
+    send := func(ctx context.Context) (*http.Response, error) {
+        req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimRight(c.Restful.Endpoint, "/")+"/request?wait=1", bytes.NewReader(payload))
+        if err != nil {
+            return nil, fmt.Errorf("failed to create request: %w", err)
+        }
+        req.Header.Set("Content-Type", "application/json")
+        req.SetBasicAuth(c.Restful.Username, c.Restful.APIKey)
+        return c.HTTPClient.Do(req)
+    }
+
+    resp, err := c.retryRequest(ctx, retryable, send, nil, applied)
+    if errors.Is(err, errAlreadyApplied) {
+        return "", err
+    }
+    if err != nil {
+        return "", fmt.Errorf("%s request failed: %w", command["prefix"], err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return "", fmt.Errorf("failed to run %s with status %d: %s", command["prefix"], resp.StatusCode, string(bodyBytes))
+    }
+
+    var result RestfulRequest
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return "", fmt.Errorf("failed to decode %s response: %w", command["prefix"], err)
+    }
+
+    if result.HasFailed {
+        var reasons []string
+        for _, failed := range result.Failed {
+            reasons = append(reasons, failed.Outs)
+        }
+        return "", fmt.Errorf("%s failed: %s", command["prefix"], strings.Join(reasons, "; "))
+    }
+
+    if !result.IsFinished || len(result.Finished) == 0 {
+        return "", fmt.Errorf("%s did not finish, request %s is %s", command["prefix"], result.ID, result.State)
+    }
+
+    return result.Finished[0].Outb, nil
+}
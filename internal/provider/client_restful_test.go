@@ -0,0 +1,86 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+// newTestRestfulServer serves the restful module's /request endpoint,
+// answering every command with the given request state
+func newTestRestfulServer(t *testing.T, result RestfulRequest, commands *[]map[string]interface{}) *httptest.Server {
+    return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != "POST" || r.URL.Path != "/request" || r.URL.Query().Get("wait") != "1" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+        }
+        if user, key, ok := r.BasicAuth(); !ok || user != "admin" || key != "key" {
+            t.Errorf("Expected basic auth with the API key, got %s:%s", user, key)
+        }
+        
+        var command map[string]interface{}
+        json.NewDecoder(r.Body).Decode(&command)
+        *commands = append(*commands, command)
+        
+        json.NewEncoder(w).Encode(result)
+    }))
+}
+
+// TestMonCommand tests that commands are sent to the restful module and
+// their output returned
+func TestMonCommand(t *testing.T) {
+    var commands []map[string]interface{}
+    server := newTestRestfulServer(t, RestfulRequest{
+        IsFinished: true,
+        State:      "success",
+        Finished:   []RestfulCommandResult{{Outb: `{"rbd":{}}`}},
+    }, &commands)
+    defer server.Close()
+    
+    client := NewCephClient("https://localhost:8443", "admin", "password")
+    client.Restful = &RestfulConfig{Endpoint: server.URL + "/", Username: "admin", APIKey: "key"}
+    
+    out, err := client.MonCommand(context.Background(), map[string]interface{}{"prefix": "osd pool application get", "pool": "rbd"}, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if out != `{"rbd":{}}` {
+        t.Errorf("Expected the command output, got %q", out)
+    }
+    if len(commands) != 1 || commands[0]["prefix"] != "osd pool application get" || commands[0]["pool"] != "rbd" {
+        t.Errorf("Unexpected commands %v", commands)
+    }
+}
+
+// TestMonCommandFailed tests that the reason of a failed command is returned
+func TestMonCommandFailed(t *testing.T) {
+    var commands []map[string]interface{}
+    server := newTestRestfulServer(t, RestfulRequest{
+        IsFinished: true,
+        HasFailed:  true,
+        State:      "failed",
+        Failed:     []RestfulCommandResult{{Outs: "pool rbd is in unmanaged snaps mode"}},
+    }, &commands)
+    defer server.Close()
+    
+    client := NewCephClient("https://localhost:8443", "admin", "password")
+    client.Restful = &RestfulConfig{Endpoint: server.URL, Username: "admin", APIKey: "key"}
+    
+    _, err := client.MonCommand(context.Background(), map[string]interface{}{"prefix": "osd pool mksnap"}, nil)
+    if err == nil || !strings.Contains(err.Error(), "unmanaged snaps mode") {
+        t.Errorf("Expected the failure reason, got %v", err)
+    }
+}
+
+// TestMonCommandNotConfigured tests that commands need the restful module
+func TestMonCommandNotConfigured(t *testing.T) {
+    client := NewCephClient("https://localhost:8443", "admin", "password")
+    
+    _, err := client.MonCommand(context.Background(), map[string]interface{}{"prefix": "osd pool mksnap"}, nil)
+    if !errors.Is(err, errRestfulNotConfigured) {
+        t.Errorf("Expected errRestfulNotConfigured, got %v", err)
+    }
+}
@@ -1,7 +1,16 @@
 package provider
 
 import (
+    "context"
+    "encoding/json"
+    "encoding/pem"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "sync/atomic"
     "testing"
+    "time"
 )
 
 // TestNewCephClient tests the client creation
@@ -20,3 +29,276 @@ func TestNewCephClient(t *testing.T) {
         t.Errorf("Expected username 'admin', got '%s'", client.Username)
     }
 }
+
+// newTestTaskServer serves a pool creation that is accepted as a background
+// task and reported as executing for the given number of polls before it
+// finishes with the given exception
+func newTestTaskServer(t *testing.T, executingPolls int, exception *TaskException) *httptest.Server {
+    task := Task{
+        Name:     "pool/create",
+        Metadata: map[string]interface{}{"pool_name": "test"},
+    }
+    polls := 0
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/pool", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusAccepted)
+        json.NewEncoder(w).Encode(task)
+    })
+    mux.HandleFunc("/api/task", func(w http.ResponseWriter, r *http.Request) {
+        if name := r.URL.Query().Get("name"); name != task.Name {
+            t.Errorf("Expected task name filter '%s', got '%s'", task.Name, name)
+        }
+        
+        var tasks TaskList
+        polls++
+        if polls <= executingPolls {
+            tasks.ExecutingTasks = []Task{task}
+        } else {
+            finished := task
+            finished.Success = exception == nil
+            finished.Exception = exception
+            tasks.FinishedTasks = []Task{finished}
+        }
+        json.NewEncoder(w).Encode(tasks)
+    })
+    
+    return httptest.NewServer(mux)
+}
+
+// TestCreatePoolWaitsForTask tests that a 202 Accepted response is followed
+// until the background task finishes
+func TestCreatePoolWaitsForTask(t *testing.T) {
+    server := newTestTaskServer(t, 2, nil)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.TaskPollInterval = time.Millisecond
+    
+    if err := client.CreatePool(context.Background(), PoolCreateRequest{Pool: "test"}); err != nil {
+        t.Fatalf("Expected pool creation to succeed, got: %s", err)
+    }
+}
+
+// TestCreatePoolTaskException tests that a failed background task is
+// surfaced as a TaskError
+func TestCreatePoolTaskException(t *testing.T) {
+    server := newTestTaskServer(t, 1, &TaskException{Detail: "pg_num 7 is not a power of two"})
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.TaskPollInterval = time.Millisecond
+    
+    err := client.CreatePool(context.Background(), PoolCreateRequest{Pool: "test"})
+    
+    var taskErr *TaskError
+    if !errors.As(err, &taskErr) {
+        t.Fatalf("Expected TaskError, got: %v", err)
+    }
+    
+    if taskErr.Detail != "pg_num 7 is not a power of two" {
+        t.Errorf("Expected task exception detail, got '%s'", taskErr.Detail)
+    }
+}
+
+// TestWaitForTaskDeadline tests that waiting stops when the context expires
+func TestWaitForTaskDeadline(t *testing.T) {
+    server := newTestTaskServer(t, 1<<30, nil)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.TaskPollInterval = time.Millisecond
+    
+    ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+    defer cancel()
+    
+    err := client.CreatePool(ctx, PoolCreateRequest{Pool: "test"})
+    if !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("Expected deadline exceeded, got: %v", err)
+    }
+}
+
+// TestReauthenticateOnUnauthorized tests that concurrent requests rejected
+// with an expired token trigger a single login and are retried
+func TestReauthenticateOnUnauthorized(t *testing.T) {
+    var logins int32
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        atomic.AddInt32(&logins, 1)
+        json.NewEncoder(w).Encode(AuthResponse{Token: "fresh"})
+    })
+    mux.HandleFunc("/api/pool/test", func(w http.ResponseWriter, r *http.Request) {
+        if r.Header.Get("Authorization") != "Bearer fresh" {
+            w.WriteHeader(http.StatusUnauthorized)
+            return
+        }
+        json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "test"})
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.Token = "expired"
+    client.tokenCheckedAt = time.Now()
+    
+    var wg sync.WaitGroup
+    for i := 0; i < 8; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := client.GetPool(context.Background(), "test"); err != nil {
+                t.Errorf("Expected request to succeed after re-authentication, got: %s", err)
+            }
+        }()
+    }
+    wg.Wait()
+    
+    if logins != 1 {
+        t.Errorf("Expected exactly 1 login, got %d", logins)
+    }
+}
+
+// TestProactiveTokenCheck tests that a stale token is validated against
+// /api/auth/check and replaced when the Dashboard no longer accepts it
+func TestProactiveTokenCheck(t *testing.T) {
+    var checks, logins int32
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        atomic.AddInt32(&logins, 1)
+        json.NewEncoder(w).Encode(AuthResponse{Token: "fresh"})
+    })
+    mux.HandleFunc("/api/auth/check", func(w http.ResponseWriter, r *http.Request) {
+        atomic.AddInt32(&checks, 1)
+        json.NewEncoder(w).Encode(map[string]interface{}{"login_url": "#/login"})
+    })
+    mux.HandleFunc("/api/pool/test", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "test"})
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    client.Token = "old"
+    
+    if _, err := client.GetPool(context.Background(), "test"); err != nil {
+        t.Fatalf("Expected request to succeed, got: %s", err)
+    }
+    
+    if checks != 1 || logins != 1 {
+        t.Errorf("Expected 1 token check and 1 login, got %d and %d", checks, logins)
+    }
+    
+    if client.Token != "fresh" {
+        t.Errorf("Expected token to be refreshed, got '%s'", client.Token)
+    }
+}
+
+// TestTLSVerification tests that the Dashboard certificate is verified by
+// default and accepted once its CA is configured
+func TestTLSVerification(t *testing.T) {
+    server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    }))
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    if err := client.Authenticate(context.Background()); err == nil {
+        t.Fatal("Expected self-signed certificate to be rejected")
+    }
+    
+    caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+    if err := client.SetTLSConfig(TLSConfig{CACertPEM: string(caPEM), ServerName: "example.com"}); err != nil {
+        t.Fatalf("Expected TLS configuration to be accepted, got: %s", err)
+    }
+    
+    if err := client.Authenticate(context.Background()); err != nil {
+        t.Fatalf("Expected certificate signed by configured CA to be accepted, got: %s", err)
+    }
+}
+
+// TestTLSConfigRequiresClientKey tests that a client certificate without a
+// key is rejected
+func TestTLSConfigRequiresClientKey(t *testing.T) {
+    if _, err := (TLSConfig{ClientCertPEM: "cert"}).Build(); err == nil {
+        t.Fatal("Expected client certificate without key to be rejected")
+    }
+}
+
+// TestPoolUnmarshal tests decoding of a Dashboard pool payload
+func TestPoolUnmarshal(t *testing.T) {
+    payload := `{
+        "pool": 3,
+        "pool_name": "rbd",
+        "type": "replicated",
+        "size": 3,
+        "min_size": 2,
+        "crush_rule": "replicated_rule",
+        "pg_num": 64,
+        "pg_placement_num": 64,
+        "pg_autoscale_mode": "on",
+        "quota_max_bytes": 1073741824,
+        "flags_names": "hashpspool,selfmanaged_snaps",
+        "options": {"compression_mode": "aggressive", "target_size_ratio": 0.2},
+        "application_metadata": ["rgw", "rbd"],
+        "stats": {"bytes_used": {"latest": 4096, "rate": 0.5}}
+    }`
+    
+    var pool Pool
+    if err := json.Unmarshal([]byte(payload), &pool); err != nil {
+        t.Fatal(err)
+    }
+    
+    if pool.ID != 3 || pool.Name != "rbd" || pool.PgpNum != 64 || pool.CrushRule != "replicated_rule" {
+        t.Errorf("Unexpected pool identity or placement: %+v", pool)
+    }
+    
+    if pool.Options.CompressionMode != "aggressive" || pool.Options.TargetSizeRatio != 0.2 {
+        t.Errorf("Unexpected pool options: %+v", pool.Options)
+    }
+    
+    if apps := pool.Applications(); len(apps) != 2 || apps[0] != "rbd" {
+        t.Errorf("Expected sorted applications [rbd rgw], got %v", apps)
+    }
+    
+    if pool.Stats == nil || pool.Stats.BytesUsed.Latest != 4096 {
+        t.Errorf("Expected stats to be decoded, got %+v", pool.Stats)
+    }
+}
+
+// TestSetApplicationKeepsEnabled tests that enabling an application sends
+// the applications already enabled along with it
+func TestSetApplicationKeepsEnabled(t *testing.T) {
+    var patched []string
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/pool/test", func(w http.ResponseWriter, r *http.Request) {
+        if r.Method == "PATCH" {
+            var body struct {
+                ApplicationMetadata []string `json:"application_metadata"`
+            }
+            json.NewDecoder(r.Body).Decode(&body)
+            patched = body.ApplicationMetadata
+            return
+        }
+        w.Write([]byte(`{"pool_name": "test", "application_metadata": ["rgw"]}`))
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    if err := client.SetApplication(context.Background(), "test", "rbd"); err != nil {
+        t.Fatal(err)
+    }
+    
+    if len(patched) != 2 || patched[0] != "rgw" || patched[1] != "rbd" {
+        t.Errorf("Expected applications [rgw rbd], got %v", patched)
+    }
+}
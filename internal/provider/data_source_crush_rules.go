@@ -0,0 +1,123 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "sort"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ datasource.DataSource              = &crushRulesDataSource{}
+    _ datasource.DataSourceWithConfigure = &crushRulesDataSource{}
+)
+
+// NewCrushRulesDataSource is a helper function to simplify the provider implementation
+func NewCrushRulesDataSource() datasource.DataSource {
+    return &crushRulesDataSource{}
+}
+
+// crushRulesDataSource is the data source implementation
+type crushRulesDataSource struct {
+    client *CephClient
+}
+
+// Metadata returns the data source type name
+func (d *crushRulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_crush_rules"
+}
+
+// Schema defines the schema for the data source
+func (d *crushRulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the CRUSH rules of the Ceph cluster.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Data source identifier",
+                Computed:    true,
+            },
+            "rules": schema.ListNestedAttribute{
+                Description: "CRUSH rules ordered by rule id",
+                Computed:    true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "rule_id": schema.Int64Attribute{
+                            Description: "Numeric id of the CRUSH rule",
+                            Computed:    true,
+                        },
+                        "name": schema.StringAttribute{
+                            Description: "Name of the CRUSH rule",
+                            Computed:    true,
+                        },
+                        "type": schema.StringAttribute{
+                            Description: "Type of the rule (replicated or erasure)",
+                            Computed:    true,
+                        },
+                        "root": schema.StringAttribute{
+                            Description: "Name of the CRUSH bucket the placement starts from",
+                            Computed:    true,
+                        },
+                        "failure_domain": schema.StringAttribute{
+                            Description: "CRUSH bucket type replicas or chunks are spread across",
+                            Computed:    true,
+                        },
+                        "device_class": schema.StringAttribute{
+                            Description: "CRUSH device class data is placed on",
+                            Computed:    true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the data source
+func (d *crushRulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *crushRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state CrushRulesDataSourceModel
+
+    rules, err := d.client.ListCrushRules(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Ceph CRUSH Rules",
+            err.Error(),
+        )
+        return
+    }
+
+    sort.Slice(rules, func(i, j int) bool { return rules[i].RuleID < rules[j].RuleID })
+
+    // Map response body to model
+    state.ID = types.StringValue("crush_rules")
+    state.Rules = make([]CrushRuleModel, 0, len(rules))
+    for i := range rules {
+        var rule CrushRuleModel
+        rule.setCrushRule(&rules[i])
+        state.Rules = append(state.Rules, rule)
+    }
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Placeholder test for CRUSH rules data source
+func TestAccCrushRulesDataSource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestCrushRulesDataSourceRead tests that rules are listed by rule id with
+// their type, root, device class and failure domain
+func TestCrushRulesDataSourceRead(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/api/crush_rule" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        w.Write([]byte(`[
+            {"rule_id": 3, "rule_name": "ec42", "type": 3, "steps": [
+                {"op": "take", "item": -1, "item_name": "default~hdd"},
+                {"op": "chooseleaf_indep", "num": 0, "type": "rack"},
+                {"op": "emit"}
+            ]},
+            {"rule_id": 0, "rule_name": "replicated_rule", "type": 1, "steps": [
+                {"op": "take", "item": -1, "item_name": "default"},
+                {"op": "chooseleaf_firstn", "num": 0, "type": "host"},
+                {"op": "emit"}
+            ]}
+        ]`))
+    }))
+    defer server.Close()
+    
+    d := &crushRulesDataSource{client: newTestRetryClient(server)}
+    s, config := testDataSourceValue(t, d, nil)
+    
+    req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}
+    resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config}}
+    d.Read(context.Background(), req, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    
+    var state CrushRulesDataSourceModel
+    resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+    if len(state.Rules) != 2 {
+        t.Fatalf("Expected 2 rules, got %+v", state.Rules)
+    }
+    
+    replicated, erasure := state.Rules[0], state.Rules[1]
+    if replicated.Name.ValueString() != "replicated_rule" || replicated.Type.ValueString() != "replicated" ||
+        replicated.FailureDomain.ValueString() != "host" || !replicated.DeviceClass.IsNull() {
+        t.Errorf("Unexpected first rule: %+v", replicated)
+    }
+    if erasure.Name.ValueString() != "ec42" || erasure.Type.ValueString() != "erasure" || erasure.Root.ValueString() != "default" ||
+        erasure.DeviceClass.ValueString() != "hdd" || erasure.FailureDomain.ValueString() != "rack" {
+        t.Errorf("Unexpected second rule: %+v", erasure)
+    }
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ datasource.DataSource              = &erasureCodeProfileDataSource{}
+    _ datasource.DataSourceWithConfigure = &erasureCodeProfileDataSource{}
+)
+
+// NewErasureCodeProfileDataSource is a helper function to simplify the provider implementation
+func NewErasureCodeProfileDataSource() datasource.DataSource {
+    return &erasureCodeProfileDataSource{}
+}
+
+// erasureCodeProfileDataSource is the data source implementation
+type erasureCodeProfileDataSource struct {
+    client *CephClient
+}
+
+// Metadata returns the data source type name
+func (d *erasureCodeProfileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_erasure_code_profile"
+}
+
+// Schema defines the schema for the data source
+func (d *erasureCodeProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Fetches information about a Ceph erasure code profile.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Erasure code profile identifier",
+                Computed:    true,
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the erasure code profile",
+                Required:    true,
+            },
+            "k": schema.Int64Attribute{
+                Description: "Number of data chunks",
+                Computed:    true,
+            },
+            "m": schema.Int64Attribute{
+                Description: "Number of coding chunks",
+                Computed:    true,
+            },
+            "plugin": schema.StringAttribute{
+                Description: "Erasure code plugin",
+                Computed:    true,
+            },
+            "technique": schema.StringAttribute{
+                Description: "Coding technique of the plugin",
+                Computed:    true,
+            },
+            "crush_failure_domain": schema.StringAttribute{
+                Description: "CRUSH bucket type across which chunks are spread",
+                Computed:    true,
+            },
+            "crush_device_class": schema.StringAttribute{
+                Description: "CRUSH device class chunks are placed on",
+                Computed:    true,
+            },
+            "crush_root": schema.StringAttribute{
+                Description: "Name of the CRUSH bucket used as the root of the placement",
+                Computed:    true,
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the data source
+func (d *erasureCodeProfileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *erasureCodeProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state ErasureCodeProfileDataSourceModel
+
+    // Read Terraform configuration data into the model
+    resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := state.Name.ValueString()
+    profile, err := d.client.GetErasureCodeProfile(ctx, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not read erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    // Map response body to model
+    state.setErasureCodeProfile(profile)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -6,7 +6,6 @@ import (
 
     "github.com/hashicorp/terraform-plugin-framework/datasource"
     "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-    "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -51,14 +50,51 @@ func (d *poolDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
                 Description: "Number of placement groups",
                 Computed:    true,
             },
+            "pgp_num": schema.Int64Attribute{
+                Description: "Number of placement groups for placement",
+                Computed:    true,
+            },
             "size": schema.Int64Attribute{
                 Description: "Replication size",
                 Computed:    true,
             },
+            "min_size": schema.Int64Attribute{
+                Description: "Minimum number of replicas or chunks required for I/O",
+                Computed:    true,
+            },
+            "crush_rule": schema.StringAttribute{
+                Description: "Name of the CRUSH rule placing the pool's data",
+                Computed:    true,
+            },
             "application": schema.StringAttribute{
                 Description: "Pool application (rbd, cephfs, rgw)",
                 Computed:    true,
             },
+            "self_managed_snapshots": schema.BoolAttribute{
+                Description: "Whether a client such as RBD manages the snapshots of the pool, which rules out pool snapshots",
+                Computed:    true,
+            },
+            "snapshots": schema.ListNestedAttribute{
+                Description: "Pool snapshots ordered by snapshot id",
+                Computed:    true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "snap_id": schema.Int64Attribute{
+                            Description: "Numeric id of the snapshot",
+                            Computed:    true,
+                        },
+                        "name": schema.StringAttribute{
+                            Description: "Name of the snapshot",
+                            Computed:    true,
+                        },
+                        "created": schema.StringAttribute{
+                            Description: "Time the snapshot was taken",
+                            Computed:    true,
+                        },
+                    },
+                },
+            },
+            "stats": poolStatsAttribute(),
         },
     }
 }
@@ -93,7 +129,7 @@ func (d *poolDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
     // Get pool information from Ceph
     poolName := state.Name.ValueString()
-    poolData, err := d.client.GetPool(poolName)
+    pool, err := d.client.GetPoolWithStats(ctx, poolName)
     if err != nil {
         resp.Diagnostics.AddError(
             "Unable to Read Ceph Pool",
@@ -103,31 +139,7 @@ func (d *poolDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
     }
 
     // Map response body to model
-    state.ID = types.StringValue(poolName)
-    state.Name = types.StringValue(poolName)
-
-    // Extract pool type
-    if poolType, ok := poolData["type"].(string); ok {
-        state.PoolType = types.StringValue(poolType)
-    }
-
-    // Extract pg_num
-    if pgNum, ok := poolData["pg_num"].(float64); ok {
-        state.PgNum = types.Int64Value(int64(pgNum))
-    }
-
-    // Extract size
-    if size, ok := poolData["size"].(float64); ok {
-        state.Size = types.Int64Value(int64(size))
-    }
-
-    // Extract application
-    if apps, ok := poolData["application_metadata"].(map[string]interface{}); ok {
-        for app := range apps {
-            state.Application = types.StringValue(app)
-            break // Take the first application
-        }
-    }
+    state.setPool(pool)
 
     // Save data into Terraform state
     resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -0,0 +1,255 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "regexp"
+    "sort"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ datasource.DataSource              = &poolsDataSource{}
+    _ datasource.DataSourceWithConfigure = &poolsDataSource{}
+)
+
+// NewPoolsDataSource is a helper function to simplify the provider implementation
+func NewPoolsDataSource() datasource.DataSource {
+    return &poolsDataSource{}
+}
+
+// poolsDataSource is the data source implementation
+type poolsDataSource struct {
+    client *CephClient
+}
+
+// Metadata returns the data source type name
+func (d *poolsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_pools"
+}
+
+// Schema defines the schema for the data source
+func (d *poolsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the pools of the Ceph cluster, optionally filtered.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Data source identifier",
+                Computed:    true,
+            },
+            "application": schema.StringAttribute{
+                Description: "Only list pools with this application enabled",
+                Optional:    true,
+            },
+            "pool_type": schema.StringAttribute{
+                Description: "Only list pools of this type (replicated or erasure)",
+                Optional:    true,
+                Validators: []validator.String{
+                    stringvalidator.OneOf(poolTypeReplicated, poolTypeErasure),
+                },
+            },
+            "name_regex": schema.StringAttribute{
+                Description: "Only list pools whose name matches this regular expression",
+                Optional:    true,
+            },
+            "crush_rule": schema.StringAttribute{
+                Description: "Only list pools placed by this CRUSH rule",
+                Optional:    true,
+            },
+            "pools": schema.ListNestedAttribute{
+                Description: "Matching pools ordered by pool id",
+                Computed:    true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "id": schema.Int64Attribute{
+                            Description: "Numeric pool id",
+                            Computed:    true,
+                        },
+                        "name": schema.StringAttribute{
+                            Description: "Name of the pool",
+                            Computed:    true,
+                        },
+                        "pool_type": schema.StringAttribute{
+                            Description: "Type of the pool (replicated or erasure)",
+                            Computed:    true,
+                        },
+                        "size": schema.Int64Attribute{
+                            Description: "Replication size, or k+m of an erasure coded pool",
+                            Computed:    true,
+                        },
+                        "min_size": schema.Int64Attribute{
+                            Description: "Minimum number of replicas or chunks required for I/O",
+                            Computed:    true,
+                        },
+                        "pg_num": schema.Int64Attribute{
+                            Description: "Number of placement groups",
+                            Computed:    true,
+                        },
+                        "pgp_num": schema.Int64Attribute{
+                            Description: "Number of placement groups for placement",
+                            Computed:    true,
+                        },
+                        "pg_autoscale_mode": schema.StringAttribute{
+                            Description: "PG autoscaler mode of the pool",
+                            Computed:    true,
+                        },
+                        "crush_rule": schema.StringAttribute{
+                            Description: "Name of the CRUSH rule placing the pool's data",
+                            Computed:    true,
+                        },
+                        "erasure_code_profile": schema.StringAttribute{
+                            Description: "Erasure code profile of an erasure coded pool",
+                            Computed:    true,
+                        },
+                        "applications": schema.ListAttribute{
+                            Description: "Applications enabled on the pool, sorted by name",
+                            ElementType: types.StringType,
+                            Computed:    true,
+                        },
+                        "quota_max_bytes": schema.Int64Attribute{
+                            Description: "Maximum number of bytes stored in the pool, 0 if unlimited",
+                            Computed:    true,
+                        },
+                        "quota_max_objects": schema.Int64Attribute{
+                            Description: "Maximum number of objects stored in the pool, 0 if unlimited",
+                            Computed:    true,
+                        },
+                        "stats": poolStatsAttribute(),
+                    },
+                },
+            },
+        },
+    }
+}
+
+// poolStatsAttribute returns the schema of the pool usage statistics
+func poolStatsAttribute() schema.SingleNestedAttribute {
+    return schema.SingleNestedAttribute{
+        Description: "Usage statistics of the pool",
+        Computed:    true,
+        Attributes: map[string]schema.Attribute{
+            "bytes_used": schema.Int64Attribute{
+                Description: "Raw bytes used by the pool, including replicas and erasure code chunks",
+                Computed:    true,
+            },
+            "stored": schema.Int64Attribute{
+                Description: "Bytes stored in the pool by clients",
+                Computed:    true,
+            },
+            "max_avail": schema.Int64Attribute{
+                Description: "Bytes that can still be stored in the pool",
+                Computed:    true,
+            },
+            "objects": schema.Int64Attribute{
+                Description: "Number of objects in the pool",
+                Computed:    true,
+            },
+            "percent_used": schema.Float64Attribute{
+                Description: "Percentage of the pool's capacity that is used",
+                Computed:    true,
+            },
+            "read_ops_rate": schema.Float64Attribute{
+                Description: "Read operations per second",
+                Computed:    true,
+            },
+            "write_ops_rate": schema.Float64Attribute{
+                Description: "Write operations per second",
+                Computed:    true,
+            },
+            "read_bytes_rate": schema.Float64Attribute{
+                Description: "Bytes read per second",
+                Computed:    true,
+            },
+            "write_bytes_rate": schema.Float64Attribute{
+                Description: "Bytes written per second",
+                Computed:    true,
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the data source
+func (d *poolsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *poolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state PoolsDataSourceModel
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    var nameRegex *regexp.Regexp
+    if !state.NameRegex.IsNull() {
+        var err error
+        nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("name_regex"),
+                "Invalid Regular Expression",
+                fmt.Sprintf("Could not compile name_regex: %s", err.Error()),
+            )
+            return
+        }
+    }
+
+    pools, err := d.client.ListPools(ctx, true)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Ceph Pools",
+            err.Error(),
+        )
+        return
+    }
+
+    sort.Slice(pools, func(i, j int) bool { return pools[i].ID < pools[j].ID })
+
+    // Map response body to model, skipping pools that do not match
+    state.ID = types.StringValue("pools")
+    state.Pools = make([]PoolModel, 0, len(pools))
+    for i := range pools {
+        pool := &pools[i]
+        if !state.Application.IsNull() && !pool.HasApplication(state.Application.ValueString()) {
+            continue
+        }
+        if !state.PoolType.IsNull() && pool.Type != state.PoolType.ValueString() {
+            continue
+        }
+        if nameRegex != nil && !nameRegex.MatchString(pool.Name) {
+            continue
+        }
+        if !state.CrushRule.IsNull() && pool.CrushRule != state.CrushRule.ValueString() {
+            continue
+        }
+
+        var model PoolModel
+        model.setPool(pool)
+        state.Pools = append(state.Pools, model)
+    }
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
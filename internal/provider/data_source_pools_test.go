@@ -0,0 +1,114 @@
+package provider
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for pools data source
+func TestAccPoolsDataSource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestPoolsDataSourceRead tests that pools are listed by id and that every
+// filter is applied
+func TestPoolsDataSourceRead(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != "/api/pool" || r.URL.Query().Get("stats") != "true" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        w.Write([]byte(`[
+            {"pool": 4, "pool_name": "ec_data", "type": "erasure", "crush_rule": "ec_data", "application_metadata": {"rbd": {}}},
+            {"pool": 2, "pool_name": "rbd-ssd", "type": "replicated", "crush_rule": "ssd_rule", "application_metadata": {"rbd": {}}},
+            {"pool": 1, "pool_name": ".mgr", "type": "replicated", "crush_rule": "replicated_rule", "application_metadata": {"mgr": {}}},
+            {"pool": 3, "pool_name": "rbd-hdd", "type": "replicated", "crush_rule": "replicated_rule", "application_metadata": {"rbd": {}}}
+        ]`))
+    }))
+    defer server.Close()
+    
+    tests := map[string]struct {
+        filters map[string]tftypes.Value
+        want    []string
+    }{
+        "all": {
+            want: []string{".mgr", "rbd-ssd", "rbd-hdd", "ec_data"},
+        },
+        "application": {
+            filters: map[string]tftypes.Value{"application": tftypes.NewValue(tftypes.String, "rbd")},
+            want:    []string{"rbd-ssd", "rbd-hdd", "ec_data"},
+        },
+        "pool type": {
+            filters: map[string]tftypes.Value{"pool_type": tftypes.NewValue(tftypes.String, "erasure")},
+            want:    []string{"ec_data"},
+        },
+        "name regex": {
+            filters: map[string]tftypes.Value{"name_regex": tftypes.NewValue(tftypes.String, "^rbd-")},
+            want:    []string{"rbd-ssd", "rbd-hdd"},
+        },
+        "crush rule": {
+            filters: map[string]tftypes.Value{"crush_rule": tftypes.NewValue(tftypes.String, "replicated_rule")},
+            want:    []string{".mgr", "rbd-hdd"},
+        },
+        "combined": {
+            filters: map[string]tftypes.Value{
+                "application": tftypes.NewValue(tftypes.String, "rbd"),
+                "pool_type":   tftypes.NewValue(tftypes.String, "replicated"),
+                "crush_rule":  tftypes.NewValue(tftypes.String, "ssd_rule"),
+            },
+            want: []string{"rbd-ssd"},
+        },
+        "no match": {
+            filters: map[string]tftypes.Value{"application": tftypes.NewValue(tftypes.String, "cephfs")},
+            want:    []string{},
+        },
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            d := &poolsDataSource{client: newTestRetryClient(server)}
+            s, config := testDataSourceValue(t, d, tt.filters)
+            
+            req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}
+            resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config}}
+            d.Read(context.Background(), req, &resp)
+            
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            
+            var state PoolsDataSourceModel
+            resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+            got := []string{}
+            for _, pool := range state.Pools {
+                got = append(got, pool.Name.ValueString())
+            }
+            if !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("Expected pools %v, got %v", tt.want, got)
+            }
+        })
+    }
+}
+
+// TestPoolsDataSourceReadInvalidRegex tests that an invalid name_regex is
+// reported on the attribute without listing pools
+func TestPoolsDataSourceReadInvalidRegex(t *testing.T) {
+    d := &poolsDataSource{}
+    s, config := testDataSourceValue(t, d, map[string]tftypes.Value{
+        "name_regex": tftypes.NewValue(tftypes.String, "rbd-("),
+    })
+    
+    req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}
+    resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config}}
+    d.Read(context.Background(), req, &resp)
+    
+    checkDiagnosticPaths(t, "error", resp.Diagnostics.Errors(), []string{"name_regex"})
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "sort"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ datasource.DataSource              = &rbdNamespacesDataSource{}
+    _ datasource.DataSourceWithConfigure = &rbdNamespacesDataSource{}
+)
+
+// NewRBDNamespacesDataSource is a helper function to simplify the provider implementation
+func NewRBDNamespacesDataSource() datasource.DataSource {
+    return &rbdNamespacesDataSource{}
+}
+
+// rbdNamespacesDataSource is the data source implementation
+type rbdNamespacesDataSource struct {
+    client *CephClient
+}
+
+// Metadata returns the data source type name
+func (d *rbdNamespacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_namespaces"
+}
+
+// Schema defines the schema for the data source
+func (d *rbdNamespacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the RBD namespaces of a pool.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Data source identifier, the pool name",
+                Computed:    true,
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool",
+                Required:    true,
+            },
+            "namespaces": schema.ListNestedAttribute{
+                Description: "Namespaces of the pool ordered by name",
+                Computed:    true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Description: "Name of the namespace",
+                            Computed:    true,
+                        },
+                        "image_count": schema.Int64Attribute{
+                            Description: "Number of images in the namespace",
+                            Computed:    true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the data source
+func (d *rbdNamespacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *rbdNamespacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state RBDNamespacesDataSourceModel
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    namespaces, err := d.client.ListRBDNamespaces(ctx, pool)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Ceph RBD Namespaces",
+            fmt.Sprintf("Could not list RBD namespaces of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Namespace < namespaces[j].Namespace })
+
+    // Map response body to model
+    state.ID = types.StringValue(pool)
+    state.Namespaces = make([]RBDNamespaceModel, 0, len(namespaces))
+    for _, ns := range namespaces {
+        state.Namespaces = append(state.Namespaces, RBDNamespaceModel{
+            Name:       types.StringValue(ns.Namespace),
+            ImageCount: types.Int64Value(ns.NumImages),
+        })
+    }
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
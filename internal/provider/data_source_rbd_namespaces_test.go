@@ -0,0 +1,102 @@
+package provider
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD namespaces data source
+func TestAccRBDNamespacesDataSource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestRBDNamespacesDataSourceRead tests that namespaces are listed by name
+// with their image count and that listing errors are reported
+func TestRBDNamespacesDataSourceRead(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch {
+        case r.Method == http.MethodGet && r.URL.Path == "/api/block/pool/rbd/namespace":
+            w.Write([]byte(`[
+                {"namespace": "tenant-b", "num_images": 0},
+                {"namespace": "tenant-a", "num_images": 3},
+                {"namespace": "ci", "num_images": 12}
+            ]`))
+        case r.Method == http.MethodGet && r.URL.Path == "/api/block/pool/empty/namespace":
+            w.Write([]byte(`[]`))
+        default:
+            w.WriteHeader(http.StatusNotFound)
+            w.Write([]byte(`{"detail": "pool not found"}`))
+        }
+    }))
+    defer server.Close()
+    
+    tests := map[string]struct {
+        pool       string
+        wantNames  []string
+        wantImages []int64
+        wantErr    bool
+    }{
+        "sorted": {
+            pool:       "rbd",
+            wantNames:  []string{"ci", "tenant-a", "tenant-b"},
+            wantImages: []int64{12, 3, 0},
+        },
+        "empty": {
+            pool:       "empty",
+            wantNames:  []string{},
+            wantImages: []int64{},
+        },
+        "missing pool": {
+            pool:    "missing",
+            wantErr: true,
+        },
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            d := &rbdNamespacesDataSource{client: newTestRetryClient(server)}
+            s, config := testDataSourceValue(t, d, map[string]tftypes.Value{
+                "pool": tftypes.NewValue(tftypes.String, tt.pool),
+            })
+            
+            req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}
+            resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config}}
+            d.Read(context.Background(), req, &resp)
+            
+            if tt.wantErr {
+                if len(resp.Diagnostics.Errors()) != 1 {
+                    t.Fatalf("Expected 1 error, got: %v", resp.Diagnostics)
+                }
+                return
+            }
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            
+            var state RBDNamespacesDataSourceModel
+            resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+            if state.ID.ValueString() != tt.pool {
+                t.Errorf("Expected id %q, got %q", tt.pool, state.ID.ValueString())
+            }
+            names := []string{}
+            images := []int64{}
+            for _, ns := range state.Namespaces {
+                names = append(names, ns.Name.ValueString())
+                images = append(images, ns.ImageCount.ValueInt64())
+            }
+            if !reflect.DeepEqual(names, tt.wantNames) {
+                t.Errorf("Expected namespaces %v, got %v", tt.wantNames, names)
+            }
+            if !reflect.DeepEqual(images, tt.wantImages) {
+                t.Errorf("Expected image counts %v, got %v", tt.wantImages, images)
+            }
+        })
+    }
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net"
+    "net/http"
+    "net/url"
+    "strings"
+    "syscall"
+)
+
+// SetEndpoints configures the Dashboard URLs of all mgr daemons. The first
+// one is used until a request shows that another mgr is active.
+func (c *CephClient) SetEndpoints(endpoints []string) error {
+    if len(endpoints) == 0 {
+        return fmt.Errorf("at least one endpoint is required")
+    }
+
+    normalized := make([]string, 0, len(endpoints))
+    for _, endpoint := range endpoints {
+        u, err := url.Parse(endpoint)
+        if err != nil || u.Scheme == "" || u.Host == "" {
+            return fmt.Errorf("invalid endpoint %q: expected a URL such as https://mgr1:8443", endpoint)
+        }
+        normalized = append(normalized, strings.TrimRight(endpoint, "/"))
+    }
+
+    c.endpointMu.Lock()
+    defer c.endpointMu.Unlock()
+
+    c.Endpoints = normalized
+    c.Endpoint = normalized[0]
+    return nil
+}
+
+// endpointList returns the configured endpoints
+func (c *CephClient) endpointList() []string {
+    c.endpointMu.RLock()
+    defer c.endpointMu.RUnlock()
+
+    if len(c.Endpoints) == 0 {
+        return []string{c.Endpoint}
+    }
+    return c.Endpoints
+}
+
+// activeEndpoint returns the endpoint requests are currently sent to
+func (c *CephClient) activeEndpoint() string {
+    c.endpointMu.RLock()
+    defer c.endpointMu.RUnlock()
+
+    return c.Endpoint
+}
+
+// setActiveEndpoint switches subsequent requests to endpoint
+func (c *CephClient) setActiveEndpoint(endpoint string) {
+    c.endpointMu.Lock()
+    defer c.endpointMu.Unlock()
+
+    c.Endpoint = endpoint
+}
+
+// failover probes all endpoints other than failed and switches to the first
+// one that is served by the active mgr. It reports whether one was found.
+func (c *CephClient) failover(ctx context.Context, failed string) bool {
+    for _, endpoint := range c.endpointList() {
+        if endpoint == failed {
+            continue
+        }
+
+        active, ok := c.probeEndpoint(ctx, endpoint)
+        if ok {
+            c.setActiveEndpoint(active)
+            return true
+        }
+    }
+    return false
+}
+
+// probeEndpoint checks whether endpoint is served by the active mgr. Standby
+// mgrs either redirect to the active one, which is then returned, or answer
+// with an error status.
+func (c *CephClient) probeEndpoint(ctx context.Context, endpoint string) (string, bool) {
+    resp, err := c.sendTo(ctx, endpoint, http.MethodGet, "/", nil, "")
+    if err != nil {
+        return "", false
+    }
+    defer resp.Body.Close()
+
+    switch resp.StatusCode {
+    case http.StatusOK:
+        return endpoint, true
+    case http.StatusSeeOther:
+        active, err := redirectEndpoint(endpoint, resp.Header.Get("Location"))
+        if err != nil {
+            return "", false
+        }
+        return active, true
+    }
+    return "", false
+}
+
+// redirectEndpoint derives the endpoint of the active mgr from the Location
+// a standby redirected to. The URL prefix of endpoint is preserved since it
+// is shared by all mgrs.
+func redirectEndpoint(endpoint, location string) (string, error) {
+    base, err := url.Parse(endpoint)
+    if err != nil {
+        return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+    }
+
+    target, err := base.Parse(location)
+    if err != nil || location == "" {
+        return "", fmt.Errorf("standby mgr at %s sent an invalid redirect %q", endpoint, location)
+    }
+
+    active := target.Scheme + "://" + target.Host + strings.TrimRight(base.Path, "/")
+    if active == endpoint {
+        return "", fmt.Errorf("mgr at %s redirected to itself", endpoint)
+    }
+    return active, nil
+}
+
+// isConnectError reports whether err means a connection to the endpoint
+// could not be established, i.e. the request was never delivered
+func isConnectError(err error) bool {
+    var opErr *net.OpError
+    if errors.As(err, &opErr) && opErr.Op == "dial" {
+        return true
+    }
+
+    var dnsErr *net.DNSError
+    if errors.As(err, &dnsErr) {
+        return true
+    }
+
+    return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EHOSTUNREACH)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// newTestActiveMgr serves the Dashboard of the active mgr
+func newTestActiveMgr() *httptest.Server {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusOK)
+    })
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/pool", func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodPost {
+            w.WriteHeader(http.StatusMethodNotAllowed)
+            return
+        }
+        w.WriteHeader(http.StatusCreated)
+    })
+    return httptest.NewServer(mux)
+}
+
+// TestStandbyRedirect tests that a POST redirected by a standby mgr is sent
+// to the active mgr unchanged and that the client keeps using it
+func TestStandbyRedirect(t *testing.T) {
+    active := newTestActiveMgr()
+    defer active.Close()
+    
+    standbyRequests := 0
+    standby := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        standbyRequests++
+        http.Redirect(w, r, active.URL+r.URL.Path, http.StatusSeeOther)
+    }))
+    defer standby.Close()
+    
+    client := NewCephClient(standby.URL, "admin", "password")
+    if err := client.SetEndpoints([]string{standby.URL, active.URL}); err != nil {
+        t.Fatal(err)
+    }
+    
+    if err := client.CreatePool(context.Background(), PoolCreateRequest{Pool: "test"}); err != nil {
+        t.Fatalf("Expected pool creation on the active mgr, got: %s", err)
+    }
+    
+    if client.Endpoint != active.URL {
+        t.Errorf("Expected active endpoint '%s', got '%s'", active.URL, client.Endpoint)
+    }
+    
+    if standbyRequests != 1 {
+        t.Errorf("Expected only the first request to hit the standby, got %d", standbyRequests)
+    }
+}
+
+// TestFailoverUnreachableEndpoint tests that an unreachable mgr is skipped
+func TestFailoverUnreachableEndpoint(t *testing.T) {
+    active := newTestActiveMgr()
+    defer active.Close()
+    
+    dead := httptest.NewServer(http.NotFoundHandler())
+    dead.Close()
+    
+    client := NewCephClient(dead.URL, "admin", "password")
+    if err := client.SetEndpoints([]string{dead.URL, active.URL}); err != nil {
+        t.Fatal(err)
+    }
+    
+    if err := client.CreatePool(context.Background(), PoolCreateRequest{Pool: "test"}); err != nil {
+        t.Fatalf("Expected failover to the reachable mgr, got: %s", err)
+    }
+    
+    if client.Endpoint != active.URL {
+        t.Errorf("Expected active endpoint '%s', got '%s'", active.URL, client.Endpoint)
+    }
+}
+
+// TestRedirectEndpoint tests that the URL prefix is kept when following a
+// standby redirect
+func TestRedirectEndpoint(t *testing.T) {
+    active, err := redirectEndpoint("https://mgr1:8443/ceph", "https://mgr2:8443/ceph/api/pool")
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    if active != "https://mgr2:8443/ceph" {
+        t.Errorf("Expected 'https://mgr2:8443/ceph', got '%s'", active)
+    }
+    
+    if _, err := redirectEndpoint("https://mgr1:8443", "/api/pool"); err == nil {
+        t.Error("Expected redirect to the same mgr to be rejected")
+    }
+}
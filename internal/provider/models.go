@@ -1,12 +1,47 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+    "sort"
+    "strconv"
+
+    "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+    "github.com/hashicorp/terraform-plugin-framework/attr"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 // CephProviderModel describes the provider configuration
 type CephProviderModel struct {
+    Endpoint       types.String `tfsdk:"endpoint"`
+    Endpoints      types.List   `tfsdk:"endpoints"`
+    Username       types.String `tfsdk:"username"`
+    Password       types.String `tfsdk:"password"`
+    Insecure       types.Bool   `tfsdk:"insecure"`
+    CACertFile     types.String `tfsdk:"ca_cert_file"`
+    CACert         types.String `tfsdk:"ca_cert"`
+    ClientCertFile types.String `tfsdk:"client_cert_file"`
+    ClientKeyFile  types.String `tfsdk:"client_key_file"`
+    ClientCert     types.String `tfsdk:"client_cert"`
+    ClientKey      types.String `tfsdk:"client_key"`
+    TLSServerName  types.String `tfsdk:"tls_server_name"`
+
+    ToggleMonAllowPoolDelete types.Bool    `tfsdk:"toggle_mon_allow_pool_delete"`
+    Retry                    *RetryModel   `tfsdk:"retry"`
+    Restful                  *RestfulModel `tfsdk:"restful"`
+}
+
+// RetryModel describes the provider retry policy configuration
+type RetryModel struct {
+    MaxAttempts          types.Int64   `tfsdk:"max_attempts"`
+    MinBackoff           types.String  `tfsdk:"min_backoff"`
+    MaxBackoff           types.String  `tfsdk:"max_backoff"`
+    RetryableStatusCodes []types.Int64 `tfsdk:"retryable_status_codes"`
+}
+
+// RestfulModel describes the provider restful module configuration
+type RestfulModel struct {
     Endpoint types.String `tfsdk:"endpoint"`
     Username types.String `tfsdk:"username"`
-    Password types.String `tfsdk:"password"`
+    APIKey   types.String `tfsdk:"api_key"`
 }
 
 // PoolResourceModel describes the pool resource
@@ -18,6 +53,32 @@ type PoolResourceModel struct {
     PgpNum      types.Int64  `tfsdk:"pgp_num"`
     Size        types.Int64  `tfsdk:"size"`
     Application types.String `tfsdk:"application"`
+
+    Applications        types.Set    `tfsdk:"applications"`
+    ApplicationMetadata types.Map    `tfsdk:"application_metadata"`
+    MinSize             types.Int64  `tfsdk:"min_size"`
+    AllowSizeOne        types.Bool   `tfsdk:"allow_size_one"`
+    DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+    ErasureCodeProfile  types.String `tfsdk:"erasure_code_profile"`
+    AllowEcOverwrites   types.Bool   `tfsdk:"allow_ec_overwrites"`
+    CrushRule           types.String `tfsdk:"crush_rule"`
+    CrushDeviceClass    types.String `tfsdk:"crush_device_class"`
+    QuotaMaxBytes       types.String `tfsdk:"quota_max_bytes"`
+    QuotaMaxObjects     types.Int64  `tfsdk:"quota_max_objects"`
+
+    PgAutoscaleMode types.String  `tfsdk:"pg_autoscale_mode"`
+    TargetSizeBytes types.String  `tfsdk:"target_size_bytes"`
+    TargetSizeRatio types.Float64 `tfsdk:"target_size_ratio"`
+    PgNumMin        types.Int64   `tfsdk:"pg_num_min"`
+    PgNumMax        types.Int64   `tfsdk:"pg_num_max"`
+
+    CompressionMode          types.String  `tfsdk:"compression_mode"`
+    CompressionAlgorithm     types.String  `tfsdk:"compression_algorithm"`
+    CompressionRequiredRatio types.Float64 `tfsdk:"compression_required_ratio"`
+    CompressionMinBlobSize   types.String  `tfsdk:"compression_min_blob_size"`
+    CompressionMaxBlobSize   types.String  `tfsdk:"compression_max_blob_size"`
+
+    Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // PoolDataSourceModel describes the pool data source
@@ -26,6 +87,659 @@ type PoolDataSourceModel struct {
     Name        types.String `tfsdk:"name"`
     PoolType    types.String `tfsdk:"pool_type"`
     PgNum       types.Int64  `tfsdk:"pg_num"`
+    PgpNum      types.Int64  `tfsdk:"pgp_num"`
     Size        types.Int64  `tfsdk:"size"`
+    MinSize     types.Int64  `tfsdk:"min_size"`
+    CrushRule   types.String `tfsdk:"crush_rule"`
     Application types.String `tfsdk:"application"`
+
+    SelfManagedSnapshots types.Bool          `tfsdk:"self_managed_snapshots"`
+    Snapshots            []PoolSnapshotModel `tfsdk:"snapshots"`
+    Stats                *PoolStatsModel     `tfsdk:"stats"`
+}
+
+// PoolSnapshotModel describes a pool snapshot listed by the pool data source
+type PoolSnapshotModel struct {
+    SnapID  types.Int64  `tfsdk:"snap_id"`
+    Name    types.String `tfsdk:"name"`
+    Created types.String `tfsdk:"created"`
+}
+
+// PoolsDataSourceModel describes the pools data source
+type PoolsDataSourceModel struct {
+    ID          types.String `tfsdk:"id"`
+    Application types.String `tfsdk:"application"`
+    PoolType    types.String `tfsdk:"pool_type"`
+    NameRegex   types.String `tfsdk:"name_regex"`
+    CrushRule   types.String `tfsdk:"crush_rule"`
+    Pools       []PoolModel  `tfsdk:"pools"`
+}
+
+// PoolModel describes a pool listed by the pools data source
+type PoolModel struct {
+    ID                 types.Int64     `tfsdk:"id"`
+    Name               types.String    `tfsdk:"name"`
+    PoolType           types.String    `tfsdk:"pool_type"`
+    Size               types.Int64     `tfsdk:"size"`
+    MinSize            types.Int64     `tfsdk:"min_size"`
+    PgNum              types.Int64     `tfsdk:"pg_num"`
+    PgpNum             types.Int64     `tfsdk:"pgp_num"`
+    PgAutoscaleMode    types.String    `tfsdk:"pg_autoscale_mode"`
+    CrushRule          types.String    `tfsdk:"crush_rule"`
+    ErasureCodeProfile types.String    `tfsdk:"erasure_code_profile"`
+    Applications       []types.String  `tfsdk:"applications"`
+    QuotaMaxBytes      types.Int64     `tfsdk:"quota_max_bytes"`
+    QuotaMaxObjects    types.Int64     `tfsdk:"quota_max_objects"`
+    Stats              *PoolStatsModel `tfsdk:"stats"`
+}
+
+// PoolStatsModel describes the usage statistics of a pool
+type PoolStatsModel struct {
+    BytesUsed      types.Int64   `tfsdk:"bytes_used"`
+    Stored         types.Int64   `tfsdk:"stored"`
+    MaxAvail       types.Int64   `tfsdk:"max_avail"`
+    Objects        types.Int64   `tfsdk:"objects"`
+    PercentUsed    types.Float64 `tfsdk:"percent_used"`
+    ReadOpsRate    types.Float64 `tfsdk:"read_ops_rate"`
+    WriteOpsRate   types.Float64 `tfsdk:"write_ops_rate"`
+    ReadBytesRate  types.Float64 `tfsdk:"read_bytes_rate"`
+    WriteBytesRate types.Float64 `tfsdk:"write_bytes_rate"`
+}
+
+// ErasureCodeProfileResourceModel describes the erasure code profile resource
+type ErasureCodeProfileResourceModel struct {
+    ID                 types.String `tfsdk:"id"`
+    Name               types.String `tfsdk:"name"`
+    K                  types.Int64  `tfsdk:"k"`
+    M                  types.Int64  `tfsdk:"m"`
+    Plugin             types.String `tfsdk:"plugin"`
+    Technique          types.String `tfsdk:"technique"`
+    CrushFailureDomain types.String `tfsdk:"crush_failure_domain"`
+    CrushDeviceClass   types.String `tfsdk:"crush_device_class"`
+    CrushRoot          types.String `tfsdk:"crush_root"`
+}
+
+// ErasureCodeProfileDataSourceModel describes the erasure code profile data source
+type ErasureCodeProfileDataSourceModel struct {
+    ID                 types.String `tfsdk:"id"`
+    Name               types.String `tfsdk:"name"`
+    K                  types.Int64  `tfsdk:"k"`
+    M                  types.Int64  `tfsdk:"m"`
+    Plugin             types.String `tfsdk:"plugin"`
+    Technique          types.String `tfsdk:"technique"`
+    CrushFailureDomain types.String `tfsdk:"crush_failure_domain"`
+    CrushDeviceClass   types.String `tfsdk:"crush_device_class"`
+    CrushRoot          types.String `tfsdk:"crush_root"`
+}
+
+// CrushRuleResourceModel describes the CRUSH rule resource
+type CrushRuleResourceModel struct {
+    ID            types.String `tfsdk:"id"`
+    Name          types.String `tfsdk:"name"`
+    RuleID        types.Int64  `tfsdk:"rule_id"`
+    Type          types.String `tfsdk:"type"`
+    Root          types.String `tfsdk:"root"`
+    FailureDomain types.String `tfsdk:"failure_domain"`
+    DeviceClass   types.String `tfsdk:"device_class"`
+}
+
+// CrushRulesDataSourceModel describes the CRUSH rules data source
+type CrushRulesDataSourceModel struct {
+    ID    types.String     `tfsdk:"id"`
+    Rules []CrushRuleModel `tfsdk:"rules"`
+}
+
+// CrushRuleModel describes a CRUSH rule listed by the CRUSH rules data source
+type CrushRuleModel struct {
+    RuleID        types.Int64  `tfsdk:"rule_id"`
+    Name          types.String `tfsdk:"name"`
+    Type          types.String `tfsdk:"type"`
+    Root          types.String `tfsdk:"root"`
+    FailureDomain types.String `tfsdk:"failure_domain"`
+    DeviceClass   types.String `tfsdk:"device_class"`
+}
+
+// RBDImageResourceModel describes the RBD image resource
+type RBDImageResourceModel struct {
+    ID          types.String `tfsdk:"id"`
+    Pool        types.String `tfsdk:"pool"`
+    Namespace   types.String `tfsdk:"namespace"`
+    Name        types.String `tfsdk:"name"`
+    Size        types.String `tfsdk:"size"`
+    AllowShrink types.Bool   `tfsdk:"allow_shrink"`
+    Features    types.Set    `tfsdk:"features"`
+    ObjectSize  types.String `tfsdk:"object_size"`
+    StripeUnit  types.String `tfsdk:"stripe_unit"`
+    StripeCount types.Int64  `tfsdk:"stripe_count"`
+    DataPool    types.String `tfsdk:"data_pool"`
+    QoS         *RBDQoSModel `tfsdk:"qos"`
+
+    Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// RBDPoolConfigResourceModel describes the RBD pool configuration resource
+type RBDPoolConfigResourceModel struct {
+    ID   types.String `tfsdk:"id"`
+    Pool types.String `tfsdk:"pool"`
+    QoS  *RBDQoSModel `tfsdk:"qos"`
+}
+
+// RBDQoSModel describes the RBD QoS overrides of an image or pool
+type RBDQoSModel struct {
+    IopsLimit      types.Int64  `tfsdk:"iops_limit"`
+    ReadIopsLimit  types.Int64  `tfsdk:"read_iops_limit"`
+    WriteIopsLimit types.Int64  `tfsdk:"write_iops_limit"`
+    BpsLimit       types.String `tfsdk:"bps_limit"`
+    ReadBpsLimit   types.String `tfsdk:"read_bps_limit"`
+    WriteBpsLimit  types.String `tfsdk:"write_bps_limit"`
+    IopsBurst      types.Int64  `tfsdk:"iops_burst"`
+    ReadIopsBurst  types.Int64  `tfsdk:"read_iops_burst"`
+    WriteIopsBurst types.Int64  `tfsdk:"write_iops_burst"`
+    BpsBurst       types.String `tfsdk:"bps_burst"`
+    ReadBpsBurst   types.String `tfsdk:"read_bps_burst"`
+    WriteBpsBurst  types.String `tfsdk:"write_bps_burst"`
+}
+
+// rbdQoSSetting ties an attribute of the qos block to its RBD option. Exactly
+// one of count and bytes is set.
+type rbdQoSSetting struct {
+    option string
+    count  *types.Int64
+    bytes  *types.String
+}
+
+// PoolSnapshotResourceModel describes the pool snapshot resource
+type PoolSnapshotResourceModel struct {
+    ID      types.String `tfsdk:"id"`
+    Pool    types.String `tfsdk:"pool"`
+    Name    types.String `tfsdk:"name"`
+    SnapID  types.Int64  `tfsdk:"snap_id"`
+    Created types.String `tfsdk:"created"`
+}
+
+// RBDNamespaceResourceModel describes the RBD namespace resource
+type RBDNamespaceResourceModel struct {
+    ID        types.String `tfsdk:"id"`
+    Pool      types.String `tfsdk:"pool"`
+    Namespace types.String `tfsdk:"namespace"`
+}
+
+// RBDNamespacesDataSourceModel describes the RBD namespaces data source
+type RBDNamespacesDataSourceModel struct {
+    ID         types.String        `tfsdk:"id"`
+    Pool       types.String        `tfsdk:"pool"`
+    Namespaces []RBDNamespaceModel `tfsdk:"namespaces"`
+}
+
+// RBDNamespaceModel describes a namespace listed by the RBD namespaces data source
+type RBDNamespaceModel struct {
+    Name       types.String `tfsdk:"name"`
+    ImageCount types.Int64  `tfsdk:"image_count"`
+}
+
+// RBDMirrorPoolResourceModel describes the RBD mirror pool resource
+type RBDMirrorPoolResourceModel struct {
+    ID         types.String `tfsdk:"id"`
+    Pool       types.String `tfsdk:"pool"`
+    MirrorMode types.String `tfsdk:"mirror_mode"`
+}
+
+// RBDMirrorBootstrapTokenResourceModel describes the RBD mirror bootstrap token resource
+type RBDMirrorBootstrapTokenResourceModel struct {
+    ID    types.String `tfsdk:"id"`
+    Pool  types.String `tfsdk:"pool"`
+    Token types.String `tfsdk:"token"`
+}
+
+// RBDMirrorPeerResourceModel describes the RBD mirror peer resource
+type RBDMirrorPeerResourceModel struct {
+    ID          types.String `tfsdk:"id"`
+    Pool        types.String `tfsdk:"pool"`
+    Token       types.String `tfsdk:"token"`
+    Direction   types.String `tfsdk:"direction"`
+    UUID        types.String `tfsdk:"uuid"`
+    ClusterName types.String `tfsdk:"cluster_name"`
+    ClientID    types.String `tfsdk:"client_id"`
+    MonHost     types.String `tfsdk:"mon_host"`
+}
+
+// RBDMirrorImageResourceModel describes the RBD mirror image resource
+type RBDMirrorImageResourceModel struct {
+    ID               types.String `tfsdk:"id"`
+    Image            types.String `tfsdk:"image"`
+    ScheduleInterval types.String `tfsdk:"schedule_interval"`
+    Primary          types.Bool   `tfsdk:"primary"`
+}
+
+// RBDSnapshotResourceModel describes the RBD snapshot resource
+type RBDSnapshotResourceModel struct {
+    ID              types.String `tfsdk:"id"`
+    Image           types.String `tfsdk:"image"`
+    Name            types.String `tfsdk:"name"`
+    Protected       types.Bool   `tfsdk:"protected"`
+    RollbackTrigger types.String `tfsdk:"rollback_trigger"`
+    SnapID          types.Int64  `tfsdk:"snap_id"`
+    Size            types.Int64  `tfsdk:"size"`
+    Created         types.String `tfsdk:"created"`
+    Children        types.Set    `tfsdk:"children"`
+
+    Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// RBDCloneResourceModel describes the RBD clone resource
+type RBDCloneResourceModel struct {
+    ID             types.String `tfsdk:"id"`
+    ParentSnapshot types.String `tfsdk:"parent_snapshot"`
+    Pool           types.String `tfsdk:"pool"`
+    Namespace      types.String `tfsdk:"namespace"`
+    Name           types.String `tfsdk:"name"`
+    Size           types.String `tfsdk:"size"`
+    AllowShrink    types.Bool   `tfsdk:"allow_shrink"`
+    Features       types.Set    `tfsdk:"features"`
+    DataPool       types.String `tfsdk:"data_pool"`
+    Flatten        types.Bool   `tfsdk:"flatten"`
+    Flattened      types.Bool   `tfsdk:"flattened"`
+
+    Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// setPool updates the model from the pool returned by the Ceph API
+func (m *PoolResourceModel) setPool(pool *Pool) {
+    m.ID = types.StringValue(strconv.FormatInt(pool.ID, 10))
+    m.Name = types.StringValue(pool.Name)
+    m.PoolType = types.StringValue(pool.Type)
+    m.PgNum = types.Int64Value(pool.PgNum)
+    m.PgpNum = types.Int64Value(pool.PgpNum)
+    m.Size = types.Int64Value(pool.Size)
+    m.MinSize = types.Int64Value(pool.MinSize)
+    m.Application = poolApplication(pool, m.Application)
+    m.Applications = poolApplications(pool)
+    m.AllowEcOverwrites = types.BoolValue(pool.HasFlag(poolFlagEcOverwrites))
+    m.CrushRule = optionalString(pool.CrushRule)
+    m.QuotaMaxBytes = optionalByteSize(pool.QuotaMaxBytes, m.QuotaMaxBytes)
+    m.QuotaMaxObjects = optionalInt64(pool.QuotaMaxObjects, m.QuotaMaxObjects)
+    m.PgAutoscaleMode = optionalString(pool.PgAutoscaleMode)
+    m.TargetSizeBytes = optionalByteSize(pool.Options.TargetSizeBytes, m.TargetSizeBytes)
+    m.TargetSizeRatio = optionalFloat64(pool.Options.TargetSizeRatio, m.TargetSizeRatio)
+    m.PgNumMin = optionalInt64(pool.Options.PgNumMin, m.PgNumMin)
+    m.PgNumMax = optionalInt64(pool.Options.PgNumMax, m.PgNumMax)
+    m.CompressionMode = optionalString(pool.Options.CompressionMode)
+    m.CompressionAlgorithm = optionalString(pool.Options.CompressionAlgorithm)
+    m.CompressionRequiredRatio = optionalFloat64(pool.Options.CompressionRequiredRatio, m.CompressionRequiredRatio)
+    m.CompressionMinBlobSize = optionalByteSize(pool.Options.CompressionMinBlobSize, m.CompressionMinBlobSize)
+    m.CompressionMaxBlobSize = optionalByteSize(pool.Options.CompressionMaxBlobSize, m.CompressionMaxBlobSize)
+
+    if pool.Type == poolTypeErasure {
+        m.ErasureCodeProfile = types.StringValue(pool.ErasureCodeProfile)
+    } else {
+        m.ErasureCodeProfile = types.StringNull()
+    }
+}
+
+// setPool updates the model from the pool returned by the Ceph API
+func (m *PoolDataSourceModel) setPool(pool *Pool) {
+    m.ID = types.StringValue(pool.Name)
+    m.Name = types.StringValue(pool.Name)
+    m.PoolType = types.StringValue(pool.Type)
+    m.PgNum = types.Int64Value(pool.PgNum)
+    m.PgpNum = types.Int64Value(pool.PgpNum)
+    m.Size = types.Int64Value(pool.Size)
+    m.MinSize = types.Int64Value(pool.MinSize)
+    m.CrushRule = optionalString(pool.CrushRule)
+    m.Application = poolApplication(pool, m.Application)
+    m.Stats = newPoolStatsModel(pool.Stats)
+
+    // The OSD map lists snapshots in no particular order
+    snapshots := append([]PoolSnapshot(nil), pool.Snapshots...)
+    sort.Slice(snapshots, func(i, j int) bool {
+        return snapshots[i].SnapID < snapshots[j].SnapID
+    })
+
+    m.SelfManagedSnapshots = types.BoolValue(pool.HasFlag(poolFlagSelfManagedSnaps))
+    m.Snapshots = make([]PoolSnapshotModel, 0, len(snapshots))
+    for _, snap := range snapshots {
+        m.Snapshots = append(m.Snapshots, PoolSnapshotModel{
+            SnapID:  types.Int64Value(snap.SnapID),
+            Name:    types.StringValue(snap.Name),
+            Created: types.StringValue(snap.Stamp),
+        })
+    }
+}
+
+// setPool updates the model from the pool returned by the Ceph API
+func (m *PoolModel) setPool(pool *Pool) {
+    m.ID = types.Int64Value(pool.ID)
+    m.Name = types.StringValue(pool.Name)
+    m.PoolType = types.StringValue(pool.Type)
+    m.Size = types.Int64Value(pool.Size)
+    m.MinSize = types.Int64Value(pool.MinSize)
+    m.PgNum = types.Int64Value(pool.PgNum)
+    m.PgpNum = types.Int64Value(pool.PgpNum)
+    m.PgAutoscaleMode = optionalString(pool.PgAutoscaleMode)
+    m.CrushRule = optionalString(pool.CrushRule)
+    m.ErasureCodeProfile = types.StringNull()
+    if pool.Type == poolTypeErasure {
+        m.ErasureCodeProfile = types.StringValue(pool.ErasureCodeProfile)
+    }
+    m.QuotaMaxBytes = types.Int64Value(pool.QuotaMaxBytes)
+    m.QuotaMaxObjects = types.Int64Value(pool.QuotaMaxObjects)
+
+    m.Applications = make([]types.String, 0, len(pool.ApplicationMetadata))
+    for _, app := range pool.Applications() {
+        m.Applications = append(m.Applications, types.StringValue(app))
+    }
+
+    m.Stats = newPoolStatsModel(pool.Stats)
+}
+
+// newPoolStatsModel returns the model of the pool statistics, or nil if the
+// pool was retrieved without them. Ceph reports percent_used as a ratio.
+func newPoolStatsModel(stats *PoolStats) *PoolStatsModel {
+    if stats == nil {
+        return nil
+    }
+
+    return &PoolStatsModel{
+        BytesUsed:      types.Int64Value(int64(stats.BytesUsed.Latest)),
+        Stored:         types.Int64Value(int64(stats.Stored.Latest)),
+        MaxAvail:       types.Int64Value(int64(stats.MaxAvail.Latest)),
+        Objects:        types.Int64Value(int64(stats.Objects.Latest)),
+        PercentUsed:    types.Float64Value(stats.PercentUsed.Latest * 100),
+        ReadOpsRate:    types.Float64Value(stats.Rd.Rate),
+        WriteOpsRate:   types.Float64Value(stats.Wr.Rate),
+        ReadBytesRate:  types.Float64Value(stats.RdBytes.Rate),
+        WriteBytesRate: types.Float64Value(stats.WrBytes.Rate),
+    }
+}
+
+// setRBDImage updates the model from the image returned by the Ceph API
+func (m *RBDImageResourceModel) setRBDImage(image *RBDImage) {
+    m.ID = types.StringValue(rbdImageSpec(image.PoolName, image.Namespace, image.Name))
+    m.Pool = types.StringValue(image.PoolName)
+    m.Namespace = optionalString(image.Namespace)
+    m.Name = types.StringValue(image.Name)
+    m.Size = optionalByteSize(image.Size, m.Size)
+    m.Features = stringSet(image.Features())
+    m.ObjectSize = optionalByteSize(image.ObjSize, m.ObjectSize)
+    m.StripeUnit = optionalByteSize(image.StripeUnit, m.StripeUnit)
+    m.StripeCount = types.Int64Value(image.StripeCount)
+    m.DataPool = optionalString(image.DataPool)
+    m.QoS = newRBDQoSModel(rbdConfigOverrides(image.Configuration, rbdConfigSourceImage), m.QoS)
+}
+
+// settings returns the attributes of the qos block with their RBD options
+func (m *RBDQoSModel) settings() []rbdQoSSetting {
+    return []rbdQoSSetting{
+        {option: "rbd_qos_iops_limit", count: &m.IopsLimit},
+        {option: "rbd_qos_read_iops_limit", count: &m.ReadIopsLimit},
+        {option: "rbd_qos_write_iops_limit", count: &m.WriteIopsLimit},
+        {option: "rbd_qos_bps_limit", bytes: &m.BpsLimit},
+        {option: "rbd_qos_read_bps_limit", bytes: &m.ReadBpsLimit},
+        {option: "rbd_qos_write_bps_limit", bytes: &m.WriteBpsLimit},
+        {option: "rbd_qos_iops_burst", count: &m.IopsBurst},
+        {option: "rbd_qos_read_iops_burst", count: &m.ReadIopsBurst},
+        {option: "rbd_qos_write_iops_burst", count: &m.WriteIopsBurst},
+        {option: "rbd_qos_bps_burst", bytes: &m.BpsBurst},
+        {option: "rbd_qos_read_bps_burst", bytes: &m.ReadBpsBurst},
+        {option: "rbd_qos_write_bps_burst", bytes: &m.WriteBpsBurst},
+    }
+}
+
+// configuration returns the RBD options set in the qos block. A nil block
+// sets none.
+func (m *RBDQoSModel) configuration() map[string]int64 {
+    configuration := make(map[string]int64)
+    if m == nil {
+        return configuration
+    }
+
+    for _, s := range m.settings() {
+        switch {
+        case s.count != nil && isKnown(*s.count):
+            configuration[s.option] = s.count.ValueInt64()
+        case s.bytes != nil && isKnown(*s.bytes):
+            // Sizes were checked by the schema validators
+            configuration[s.option], _ = parseByteSize(s.bytes.ValueString())
+        }
+    }
+    return configuration
+}
+
+// rbdQoSChanges returns the RBD options to change to get from the current to
+// the planned qos block. Options no longer set map to nil, which removes the
+// override so that the value is inherited again.
+func rbdQoSChanges(planned, current *RBDQoSModel) map[string]interface{} {
+    want := planned.configuration()
+    have := current.configuration()
+
+    changes := make(map[string]interface{})
+    for option, v := range want {
+        if old, ok := have[option]; !ok || old != v {
+            changes[option] = v
+        }
+    }
+    for option := range have {
+        if _, ok := want[option]; !ok {
+            changes[option] = nil
+        }
+    }
+    return changes
+}
+
+// newRBDQoSModel returns the qos block for the overridden RBD options. The
+// block stays absent while nothing is overridden and it was not configured,
+// and sizes keep the current value while it denotes the same number of bytes.
+func newRBDQoSModel(overrides map[string]int64, current *RBDQoSModel) *RBDQoSModel {
+    if len(overrides) == 0 && current == nil {
+        return nil
+    }
+    if current == nil {
+        current = &RBDQoSModel{}
+    }
+
+    m := &RBDQoSModel{}
+    currentSettings := current.settings()
+    for i, s := range m.settings() {
+        v, ok := overrides[s.option]
+        switch {
+        case s.count != nil && ok:
+            *s.count = types.Int64Value(v)
+        case s.count != nil:
+            *s.count = types.Int64Null()
+        case ok:
+            *s.bytes = optionalByteSize(v, *currentSettings[i].bytes)
+            if s.bytes.IsNull() {
+                // An explicit 0 override, which optionalByteSize treats as unset
+                *s.bytes = types.StringValue("0")
+            }
+        default:
+            *s.bytes = types.StringNull()
+        }
+    }
+    return m
+}
+
+// setPoolSnapshot updates the model from a snapshot of the given pool
+func (m *PoolSnapshotResourceModel) setPoolSnapshot(pool string, snapshot *PoolSnapshot) {
+    m.ID = types.StringValue(poolSnapshotID(pool, snapshot.Name))
+    m.Pool = types.StringValue(pool)
+    m.Name = types.StringValue(snapshot.Name)
+    m.SnapID = types.Int64Value(snapshot.SnapID)
+    m.Created = optionalString(snapshot.Stamp)
+}
+
+// setRBDSnapshot updates the model from the snapshot returned by the Ceph API
+func (m *RBDSnapshotResourceModel) setRBDSnapshot(imageSpec string, snapshot *RBDSnapshot) {
+    m.ID = types.StringValue(rbdSnapshotSpec(imageSpec, snapshot.Name))
+    m.Image = types.StringValue(imageSpec)
+    m.Name = types.StringValue(snapshot.Name)
+    m.Protected = types.BoolValue(snapshot.IsProtected)
+    m.SnapID = types.Int64Value(snapshot.ID)
+    m.Size = types.Int64Value(snapshot.Size)
+    m.Created = optionalString(snapshot.Timestamp)
+    m.Children = stringSet(snapshot.ChildSpecs())
+}
+
+// setRBDMirrorPeer updates the model from the peer returned by the Ceph API
+func (m *RBDMirrorPeerResourceModel) setRBDMirrorPeer(pool string, peer *RBDMirrorPeer) {
+    m.ID = types.StringValue(pool + "/" + peer.UUID)
+    m.Pool = types.StringValue(pool)
+    m.UUID = types.StringValue(peer.UUID)
+    m.ClusterName = optionalString(peer.ClusterName)
+    m.ClientID = types.StringValue(peer.ClientID)
+    m.MonHost = types.StringValue(peer.MonHost)
+}
+
+// setRBDClone updates the model from the clone returned by the Ceph API. The
+// parent snapshot is kept once the clone has been flattened.
+func (m *RBDCloneResourceModel) setRBDClone(image *RBDImage) {
+    m.ID = types.StringValue(rbdImageSpec(image.PoolName, image.Namespace, image.Name))
+    m.Pool = types.StringValue(image.PoolName)
+    m.Namespace = optionalString(image.Namespace)
+    m.Name = types.StringValue(image.Name)
+    m.Size = optionalByteSize(image.Size, m.Size)
+    m.Features = stringSet(image.Features())
+    m.DataPool = optionalString(image.DataPool)
+    m.Flattened = types.BoolValue(image.Parent == nil)
+    if image.Parent != nil {
+        m.ParentSnapshot = types.StringValue(image.Parent.SnapshotSpec())
+    }
+}
+
+// poolApplication returns the application to report for the pool. The
+// current value is kept while it is still enabled, otherwise the first
+// application in sorted order is used so that the result is stable.
+func poolApplication(pool *Pool, current types.String) types.String {
+    if !current.IsNull() && !current.IsUnknown() && pool.HasApplication(current.ValueString()) {
+        return current
+    }
+
+    apps := pool.Applications()
+    if len(apps) == 0 {
+        if current.IsUnknown() {
+            return types.StringNull()
+        }
+        return current
+    }
+    return types.StringValue(apps[0])
+}
+
+// poolApplications returns the applications enabled on the pool as a set
+func poolApplications(pool *Pool) types.Set {
+    return stringSet(pool.Applications())
+}
+
+// stringSet returns the values as a set of strings
+func stringSet(values []string) types.Set {
+    elements := make([]attr.Value, 0, len(values))
+    for _, v := range values {
+        elements = append(elements, types.StringValue(v))
+    }
+    return types.SetValueMust(types.StringType, elements)
+}
+
+// setErasureCodeProfile updates the model from the profile returned by the Ceph API
+func (m *ErasureCodeProfileResourceModel) setErasureCodeProfile(profile *ErasureCodeProfile) {
+    m.ID = types.StringValue(profile.Name)
+    m.Name = types.StringValue(profile.Name)
+    m.K = types.Int64Value(int64(profile.K))
+    m.M = types.Int64Value(int64(profile.M))
+    m.Plugin = optionalString(profile.Plugin)
+    m.Technique = optionalString(profile.Technique)
+    m.CrushFailureDomain = optionalString(profile.CrushFailureDomain)
+    m.CrushDeviceClass = optionalString(profile.CrushDeviceClass)
+    m.CrushRoot = optionalString(profile.CrushRoot)
+}
+
+// setErasureCodeProfile updates the model from the profile returned by the Ceph API
+func (m *ErasureCodeProfileDataSourceModel) setErasureCodeProfile(profile *ErasureCodeProfile) {
+    m.ID = types.StringValue(profile.Name)
+    m.Name = types.StringValue(profile.Name)
+    m.K = types.Int64Value(int64(profile.K))
+    m.M = types.Int64Value(int64(profile.M))
+    m.Plugin = optionalString(profile.Plugin)
+    m.Technique = optionalString(profile.Technique)
+    m.CrushFailureDomain = optionalString(profile.CrushFailureDomain)
+    m.CrushDeviceClass = optionalString(profile.CrushDeviceClass)
+    m.CrushRoot = optionalString(profile.CrushRoot)
+}
+
+// setCrushRule updates the model from the rule returned by the Ceph API
+func (m *CrushRuleResourceModel) setCrushRule(rule *CrushRule) {
+    root, deviceClass := rule.Root()
+
+    m.ID = types.StringValue(rule.RuleName)
+    m.Name = types.StringValue(rule.RuleName)
+    m.RuleID = types.Int64Value(rule.RuleID)
+    m.Type = types.StringValue(rule.TypeName())
+    m.Root = optionalString(root)
+    m.FailureDomain = optionalString(rule.FailureDomain())
+    m.DeviceClass = optionalString(deviceClass)
+}
+
+// setCrushRule updates the model from the rule returned by the Ceph API
+func (m *CrushRuleModel) setCrushRule(rule *CrushRule) {
+    root, deviceClass := rule.Root()
+
+    m.RuleID = types.Int64Value(rule.RuleID)
+    m.Name = types.StringValue(rule.RuleName)
+    m.Type = types.StringValue(rule.TypeName())
+    m.Root = optionalString(root)
+    m.FailureDomain = optionalString(rule.FailureDomain())
+    m.DeviceClass = optionalString(deviceClass)
+}
+
+// optionalByteSize returns the Terraform value for a size in bytes where 0
+// means unset. The current value is kept while it denotes the same number of
+// bytes, so that sizes written as e.g. "500GiB" do not show up as a
+// difference.
+func optionalByteSize(bytes int64, current types.String) types.String {
+    if isKnown(current) {
+        if n, err := parseByteSize(current.ValueString()); err == nil && n == bytes {
+            return current
+        }
+    }
+
+    if bytes == 0 {
+        return types.StringNull()
+    }
+    return types.StringValue(strconv.FormatInt(bytes, 10))
+}
+
+// optionalInt64 returns the Terraform value for a number where 0 means unset,
+// keeping an explicitly configured 0
+func optionalInt64(v int64, current types.Int64) types.Int64 {
+    if v == 0 && !(isKnown(current) && current.ValueInt64() == 0) {
+        return types.Int64Null()
+    }
+    return types.Int64Value(v)
+}
+
+// optionalFloat64 returns the Terraform value for a number where 0 means
+// unset, keeping an explicitly configured 0
+func optionalFloat64(v float64, current types.Float64) types.Float64 {
+    if v == 0 && !(isKnown(current) && current.ValueFloat64() == 0) {
+        return types.Float64Null()
+    }
+    return types.Float64Value(v)
+}
+
+// optionalString maps values the Ceph API leaves empty to null
+func optionalString(s string) types.String {
+    if s == "" {
+        return types.StringNull()
+    }
+    return types.StringValue(s)
+}
+
+// knownValue is implemented by all Terraform value types
+type knownValue interface {
+    IsNull() bool
+    IsUnknown() bool
+}
+
+// isKnown reports whether v holds a value, i.e. it is neither null nor
+// unknown, as is the case for unset Optional+Computed attributes in a plan
+func isKnown(v knownValue) bool {
+    return !v.IsNull() && !v.IsUnknown()
 }
@@ -0,0 +1,76 @@
+package provider
+
+import (
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestRBDQoSChanges tests that changed settings are set and removed ones are
+// cleared so that they are inherited again
+func TestRBDQoSChanges(t *testing.T) {
+    current := &RBDQoSModel{
+        IopsLimit: types.Int64Value(1000),
+        BpsLimit:  types.StringValue("100MiB"),
+        IopsBurst: types.Int64Value(2000),
+    }
+    planned := &RBDQoSModel{
+        IopsLimit: types.Int64Value(1000),
+        BpsLimit:  types.StringValue("200MiB"),
+    }
+    
+    changes := rbdQoSChanges(planned, current)
+    if len(changes) != 2 {
+        t.Fatalf("Expected 2 changes, got %v", changes)
+    }
+    if changes["rbd_qos_bps_limit"] != int64(200<<20) {
+        t.Errorf("Expected rbd_qos_bps_limit to be set to 200MiB, got %v", changes["rbd_qos_bps_limit"])
+    }
+    if v, ok := changes["rbd_qos_iops_burst"]; !ok || v != nil {
+        t.Errorf("Expected rbd_qos_iops_burst to be removed, got %v", v)
+    }
+    
+    if changes := rbdQoSChanges(nil, current); len(changes) != 3 {
+        t.Errorf("Expected all 3 overrides to be removed, got %v", changes)
+    }
+}
+
+// TestNewRBDQoSModel tests that sizes keep the configured spelling and that
+// the block stays absent without overrides
+func TestNewRBDQoSModel(t *testing.T) {
+    if m := newRBDQoSModel(map[string]int64{}, nil); m != nil {
+        t.Errorf("Expected no qos block, got %+v", m)
+    }
+    
+    current := &RBDQoSModel{BpsLimit: types.StringValue("100MiB")}
+    m := newRBDQoSModel(map[string]int64{"rbd_qos_bps_limit": 100 << 20, "rbd_qos_iops_limit": 0}, current)
+    if m.BpsLimit.ValueString() != "100MiB" {
+        t.Errorf("Expected bps_limit '100MiB', got %s", m.BpsLimit)
+    }
+    if m.IopsLimit.IsNull() || m.IopsLimit.ValueInt64() != 0 {
+        t.Errorf("Expected an explicit iops_limit of 0, got %s", m.IopsLimit)
+    }
+    if !m.WriteBpsLimit.IsNull() {
+        t.Errorf("Expected write_bps_limit to be inherited, got %s", m.WriteBpsLimit)
+    }
+}
+
+// TestPoolDataSourceSnapshotsSorted tests that pool snapshots are listed by
+// snapshot id whatever order the OSD map has them in
+func TestPoolDataSourceSnapshotsSorted(t *testing.T) {
+    var m PoolDataSourceModel
+    m.setPool(&Pool{
+        Name: "data",
+        Snapshots: []PoolSnapshot{
+            {SnapID: 3, Name: "c"},
+            {SnapID: 1, Name: "a"},
+            {SnapID: 2, Name: "b"},
+        },
+    })
+    
+    for i, snap := range m.Snapshots {
+        if snap.SnapID.ValueInt64() != int64(i+1) {
+            t.Errorf("Expected snapshot %d at position %d, got %d", i+1, i, snap.SnapID.ValueInt64())
+        }
+    }
+}
@@ -2,14 +2,19 @@ package provider
 
 import (
     "context"
+    "fmt"
     "os"
+    "strconv"
+    "strings"
+    "time"
 
     "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
     "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/provider"
     "github.com/hashicorp/terraform-plugin-framework/provider/schema"
     "github.com/hashicorp/terraform-plugin-framework/resource"
-    // Removed the unused import: "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -43,9 +48,14 @@ func (p *cephProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
         Description: "Interact with Ceph cluster via REST API.",
         Attributes: map[string]schema.Attribute{
             "endpoint": schema.StringAttribute{
-                Description: "The Ceph API endpoint URL. Can also be set via CEPH_ENDPOINT environment variable.",
+                Description: "The Ceph API endpoint URL. Can also be set via CEPH_ENDPOINT environment variable. Conflicts with endpoints.",
                 Optional:    true,
             },
+            "endpoints": schema.ListAttribute{
+                Description: "The Ceph API endpoint URLs of all mgr daemons. Requests go to the active mgr and fail over when another mgr takes over. Can also be set as a comma separated list via CEPH_ENDPOINTS environment variable. Conflicts with endpoint.",
+                Optional:    true,
+                ElementType: types.StringType,
+            },
             "username": schema.StringAttribute{
                 Description: "The username for Ceph API authentication. Can also be set via CEPH_USERNAME environment variable.",
                 Optional:    true,
@@ -55,6 +65,85 @@ func (p *cephProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
                 Optional:    true,
                 Sensitive:   true,
             },
+            "insecure": schema.BoolAttribute{
+                Description: "Skip verification of the Ceph API TLS certificate. Only use this for testing. Can also be set via CEPH_INSECURE environment variable. Defaults to false.",
+                Optional:    true,
+            },
+            "ca_cert_file": schema.StringAttribute{
+                Description: "Path to a PEM encoded CA certificate bundle used to verify the Ceph API certificate. Can also be set via CEPH_CA_CERT_FILE environment variable.",
+                Optional:    true,
+            },
+            "ca_cert": schema.StringAttribute{
+                Description: "PEM encoded CA certificate bundle used to verify the Ceph API certificate. Can also be set via CEPH_CA_CERT environment variable.",
+                Optional:    true,
+            },
+            "client_cert_file": schema.StringAttribute{
+                Description: "Path to a PEM encoded client certificate for mutual TLS. Can also be set via CEPH_CLIENT_CERT_FILE environment variable.",
+                Optional:    true,
+            },
+            "client_key_file": schema.StringAttribute{
+                Description: "Path to the PEM encoded private key of the client certificate. Can also be set via CEPH_CLIENT_KEY_FILE environment variable.",
+                Optional:    true,
+            },
+            "client_cert": schema.StringAttribute{
+                Description: "PEM encoded client certificate for mutual TLS. Can also be set via CEPH_CLIENT_CERT environment variable.",
+                Optional:    true,
+            },
+            "client_key": schema.StringAttribute{
+                Description: "PEM encoded private key of the client certificate. Can also be set via CEPH_CLIENT_KEY environment variable.",
+                Optional:    true,
+                Sensitive:   true,
+            },
+            "tls_server_name": schema.StringAttribute{
+                Description: "Server name used to verify the Ceph API certificate, if it differs from the endpoint host. Can also be set via CEPH_TLS_SERVER_NAME environment variable.",
+                Optional:    true,
+            },
+            "toggle_mon_allow_pool_delete": schema.BoolAttribute{
+                Description: "Enable mon_allow_pool_delete through the cluster configuration while deleting pools and restore its previous value afterwards. Pool deletions are then run one at a time. Can also be set via CEPH_TOGGLE_MON_ALLOW_POOL_DELETE environment variable. Defaults to false.",
+                Optional:    true,
+            },
+            "retry": schema.SingleNestedAttribute{
+                Description: "Retry policy for transient Ceph API failures such as mgr failovers. Only idempotent requests, or requests whose outcome can be verified, are retried.",
+                Optional:    true,
+                Attributes: map[string]schema.Attribute{
+                    "max_attempts": schema.Int64Attribute{
+                        Description: "Total number of attempts per request, including the first one. Set to 1 to disable retries. Defaults to 5.",
+                        Optional:    true,
+                    },
+                    "min_backoff": schema.StringAttribute{
+                        Description: "Initial wait between attempts as a duration, e.g. \"1s\". Doubles after every attempt. Defaults to 1s.",
+                        Optional:    true,
+                    },
+                    "max_backoff": schema.StringAttribute{
+                        Description: "Upper bound of the wait between attempts as a duration, e.g. \"30s\". A Retry-After header sent by the Ceph API takes precedence. Defaults to 30s.",
+                        Optional:    true,
+                    },
+                    "retryable_status_codes": schema.ListAttribute{
+                        Description: "HTTP status codes that are retried. Defaults to [502, 503, 504].",
+                        Optional:    true,
+                        ElementType: types.Int64Type,
+                    },
+                },
+            },
+            "restful": schema.SingleNestedAttribute{
+                Description: "Connection to the mgr restful module (`ceph mgr module enable restful`), which runs the mon commands the Dashboard API does not expose. Only needed by ceph_pool_snapshot and the application_metadata of ceph_pool; everything else uses the Dashboard API alone. The module is deprecated and disabled by default in current Ceph releases. Its certificate is verified with the TLS settings of the Ceph API.",
+                Optional:    true,
+                Attributes: map[string]schema.Attribute{
+                    "endpoint": schema.StringAttribute{
+                        Description: "The restful module URL, e.g. https://mgr:8003. Can also be set via CEPH_RESTFUL_ENDPOINT environment variable.",
+                        Optional:    true,
+                    },
+                    "username": schema.StringAttribute{
+                        Description: "The user the API key was created for. Can also be set via CEPH_RESTFUL_USERNAME environment variable.",
+                        Optional:    true,
+                    },
+                    "api_key": schema.StringAttribute{
+                        Description: "The API key created by `ceph restful create-key`. Can also be set via CEPH_RESTFUL_API_KEY environment variable.",
+                        Optional:    true,
+                        Sensitive:   true,
+                    },
+                },
+            },
         },
     }
 }
@@ -98,6 +187,48 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
         )
     }
 
+    for _, attr := range []struct {
+        name  string
+        value types.String
+    }{
+        {"ca_cert_file", config.CACertFile},
+        {"ca_cert", config.CACert},
+        {"client_cert_file", config.ClientCertFile},
+        {"client_key_file", config.ClientKeyFile},
+        {"client_cert", config.ClientCert},
+        {"client_key", config.ClientKey},
+        {"tls_server_name", config.TLSServerName},
+    } {
+        if attr.value.IsUnknown() {
+            addUnknownAttributeError(&resp.Diagnostics, attr.name)
+        }
+    }
+
+    if config.Insecure.IsUnknown() {
+        addUnknownAttributeError(&resp.Diagnostics, "insecure")
+    }
+
+    if config.Endpoints.IsUnknown() {
+        addUnknownAttributeError(&resp.Diagnostics, "endpoints")
+    }
+
+    if config.ToggleMonAllowPoolDelete.IsUnknown() {
+        addUnknownAttributeError(&resp.Diagnostics, "toggle_mon_allow_pool_delete")
+    }
+
+    retryPolicy := DefaultRetryPolicy()
+    if config.Retry != nil {
+        resp.Diagnostics.Append(config.Retry.apply(&retryPolicy)...)
+    }
+
+    // Without a restful block the environment variables may still set it up
+    restfulModel := config.Restful
+    if restfulModel == nil {
+        restfulModel = &RestfulModel{}
+    }
+    restful, diags := restfulModel.config()
+    resp.Diagnostics.Append(diags...)
+
     if resp.Diagnostics.HasError() {
         return
     }
@@ -109,8 +240,34 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
     username := os.Getenv("CEPH_USERNAME")
     password := os.Getenv("CEPH_PASSWORD")
 
+    var endpoints []string
+    if v := os.Getenv("CEPH_ENDPOINTS"); v != "" {
+        for _, e := range strings.Split(v, ",") {
+            if e = strings.TrimSpace(e); e != "" {
+                endpoints = append(endpoints, e)
+            }
+        }
+    }
+
     if !config.Endpoint.IsNull() {
         endpoint = config.Endpoint.ValueString()
+        endpoints = nil
+    }
+
+    if !config.Endpoints.IsNull() {
+        if !config.Endpoint.IsNull() {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("endpoints"),
+                "Conflicting Ceph API Endpoint Configuration",
+                "Only one of endpoint and endpoints may be set.",
+            )
+        }
+        endpoints = nil
+        resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+    }
+
+    if len(endpoints) > 0 {
+        endpoint = endpoints[0]
     }
 
     if !config.Username.IsNull() {
@@ -121,6 +278,49 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
         password = config.Password.ValueString()
     }
 
+    tlsConfig := TLSConfig{
+        CACertFile:     stringValueOrEnv(config.CACertFile, "CEPH_CA_CERT_FILE"),
+        CACertPEM:      stringValueOrEnv(config.CACert, "CEPH_CA_CERT"),
+        ClientCertFile: stringValueOrEnv(config.ClientCertFile, "CEPH_CLIENT_CERT_FILE"),
+        ClientKeyFile:  stringValueOrEnv(config.ClientKeyFile, "CEPH_CLIENT_KEY_FILE"),
+        ClientCertPEM:  stringValueOrEnv(config.ClientCert, "CEPH_CLIENT_CERT"),
+        ClientKeyPEM:   stringValueOrEnv(config.ClientKey, "CEPH_CLIENT_KEY"),
+        ServerName:     stringValueOrEnv(config.TLSServerName, "CEPH_TLS_SERVER_NAME"),
+    }
+
+    if v := os.Getenv("CEPH_INSECURE"); v != "" {
+        insecure, err := strconv.ParseBool(v)
+        if err != nil {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("insecure"),
+                "Invalid CEPH_INSECURE Value",
+                "The CEPH_INSECURE environment variable must be a boolean value: "+err.Error(),
+            )
+        }
+        tlsConfig.Insecure = insecure
+    }
+
+    if !config.Insecure.IsNull() {
+        tlsConfig.Insecure = config.Insecure.ValueBool()
+    }
+
+    var togglePoolDelete bool
+    if v := os.Getenv("CEPH_TOGGLE_MON_ALLOW_POOL_DELETE"); v != "" {
+        toggle, err := strconv.ParseBool(v)
+        if err != nil {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("toggle_mon_allow_pool_delete"),
+                "Invalid CEPH_TOGGLE_MON_ALLOW_POOL_DELETE Value",
+                "The CEPH_TOGGLE_MON_ALLOW_POOL_DELETE environment variable must be a boolean value: "+err.Error(),
+            )
+        }
+        togglePoolDelete = toggle
+    }
+
+    if !config.ToggleMonAllowPoolDelete.IsNull() {
+        togglePoolDelete = config.ToggleMonAllowPoolDelete.ValueBool()
+    }
+
     // If any of the expected configurations are missing, return
     // errors with provider-specific guidance.
 
@@ -129,7 +329,7 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
             path.Root("endpoint"),
             "Missing Ceph API Endpoint",
             "The provider cannot create the Ceph API client as there is a missing or empty value for the Ceph API endpoint. "+
-                "Set the endpoint value in the configuration or use the CEPH_ENDPOINT environment variable. "+
+                "Set the endpoint or endpoints value in the configuration or use the CEPH_ENDPOINT or CEPH_ENDPOINTS environment variable. "+
                 "If either is already set, ensure the value is not empty.",
         )
     }
@@ -161,6 +361,47 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
     // Create a new Ceph client using the configuration values
     client := NewCephClient(endpoint, username, password)
 
+    if len(endpoints) > 0 {
+        if err := client.SetEndpoints(endpoints); err != nil {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("endpoints"),
+                "Invalid Ceph API Endpoint",
+                "The provider cannot create the Ceph API client: "+err.Error(),
+            )
+            return
+        }
+    }
+
+    if err := client.SetTLSConfig(tlsConfig); err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Ceph API TLS Configuration",
+            "The provider cannot create the Ceph API client as the TLS configuration is invalid: "+err.Error(),
+        )
+        return
+    }
+
+    client.Retry = retryPolicy
+    client.ToggleMonAllowPoolDelete = togglePoolDelete
+    client.Restful = restful
+
+    version, err := client.DetectVersion(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Detect Ceph Version",
+            "The provider could not determine the Ceph release of the cluster from the Ceph API: "+err.Error(),
+        )
+        return
+    }
+
+    if !version.Supported() {
+        resp.Diagnostics.AddError(
+            "Unsupported Ceph Version",
+            fmt.Sprintf("The cluster runs Ceph %s, but the provider requires Ceph %d (%s) or newer.",
+                version, minSupportedCephVersion, cephReleaseNames[minSupportedCephVersion]),
+        )
+        return
+    }
+
     // Make the Ceph client available during DataSource and Resource
     // type Configure methods.
     resp.DataSourceData = client
@@ -171,6 +412,10 @@ func (p *cephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *cephProvider) DataSources(_ context.Context) []func() datasource.DataSource {
     return []func() datasource.DataSource{
         NewPoolDataSource,
+        NewErasureCodeProfileDataSource,
+        NewCrushRulesDataSource,
+        NewPoolsDataSource,
+        NewRBDNamespacesDataSource,
     }
 }
 
@@ -178,5 +423,146 @@ func (p *cephProvider) DataSources(_ context.Context) []func() datasource.DataSo
 func (p *cephProvider) Resources(_ context.Context) []func() resource.Resource {
     return []func() resource.Resource{
         NewPoolResource,
+        NewPoolSnapshotResource,
+        NewErasureCodeProfileResource,
+        NewCrushRuleResource,
+        NewRBDImageResource,
+        NewRBDSnapshotResource,
+        NewRBDCloneResource,
+        NewRBDNamespaceResource,
+        NewRBDMirrorPoolResource,
+        NewRBDMirrorBootstrapTokenResource,
+        NewRBDMirrorPeerResource,
+        NewRBDMirrorImageResource,
+        NewRBDPoolConfigResource,
+    }
+}
+
+// stringValueOrEnv returns the configured value, falling back to the given
+// environment variable when the attribute is not set
+func stringValueOrEnv(value types.String, env string) string {
+    if !value.IsNull() {
+        return value.ValueString()
+    }
+    return os.Getenv(env)
+}
+
+// addUnknownAttributeError reports an optional provider attribute whose
+// value is not known at configuration time
+func addUnknownAttributeError(diags *diag.Diagnostics, attr string) {
+    diags.AddAttributeError(
+        path.Root(attr),
+        "Unknown Ceph Provider Configuration Value",
+        "The provider cannot create the Ceph API client as there is an unknown configuration value for "+attr+". "+
+            "Either target apply the source of the value first or set the value statically in the configuration.",
+    )
+}
+
+// apply overrides the fields of policy that are set in the configuration
+func (m *RetryModel) apply(policy *RetryPolicy) diag.Diagnostics {
+    var diags diag.Diagnostics
+
+    if m.MaxAttempts.IsUnknown() || m.MinBackoff.IsUnknown() || m.MaxBackoff.IsUnknown() {
+        addUnknownAttributeError(&diags, "retry")
+        return diags
+    }
+
+    if !m.MaxAttempts.IsNull() {
+        if m.MaxAttempts.ValueInt64() < 1 {
+            diags.AddAttributeError(
+                path.Root("retry").AtName("max_attempts"),
+                "Invalid Retry Configuration",
+                "max_attempts must be at least 1.",
+            )
+        }
+        policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+    }
+
+    for _, backoff := range []struct {
+        name  string
+        value types.String
+        field *time.Duration
+    }{
+        {"min_backoff", m.MinBackoff, &policy.MinBackoff},
+        {"max_backoff", m.MaxBackoff, &policy.MaxBackoff},
+    } {
+        if backoff.value.IsNull() {
+            continue
+        }
+        d, err := time.ParseDuration(backoff.value.ValueString())
+        if err != nil || d < 0 {
+            diags.AddAttributeError(
+                path.Root("retry").AtName(backoff.name),
+                "Invalid Retry Configuration",
+                backoff.name+" must be a non-negative duration such as \"1s\" or \"500ms\".",
+            )
+            continue
+        }
+        *backoff.field = d
+    }
+
+    if policy.MinBackoff > policy.MaxBackoff {
+        diags.AddAttributeError(
+            path.Root("retry").AtName("min_backoff"),
+            "Invalid Retry Configuration",
+            "min_backoff must not be greater than max_backoff.",
+        )
+    }
+
+    if m.RetryableStatusCodes != nil {
+        policy.RetryableStatusCodes = make([]int, 0, len(m.RetryableStatusCodes))
+        for _, code := range m.RetryableStatusCodes {
+            if code.IsUnknown() {
+                addUnknownAttributeError(&diags, "retry")
+                return diags
+            }
+            policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, int(code.ValueInt64()))
+        }
+    }
+
+    return diags
+}
+
+// config returns the restful module configuration, with unset attributes
+// taken from the environment, or nil if no endpoint is set
+func (m *RestfulModel) config() (*RestfulConfig, diag.Diagnostics) {
+    var diags diag.Diagnostics
+
+    if m.Endpoint.IsUnknown() || m.Username.IsUnknown() || m.APIKey.IsUnknown() {
+        addUnknownAttributeError(&diags, "restful")
+        return nil, diags
+    }
+
+    restful := &RestfulConfig{
+        Endpoint: stringValueOrEnv(m.Endpoint, "CEPH_RESTFUL_ENDPOINT"),
+        Username: stringValueOrEnv(m.Username, "CEPH_RESTFUL_USERNAME"),
+        APIKey:   stringValueOrEnv(m.APIKey, "CEPH_RESTFUL_API_KEY"),
+    }
+
+    if restful.Endpoint == "" {
+        return nil, diags
     }
-}
\ No newline at end of file
+
+    if restful.Username == "" || restful.APIKey == "" {
+        diags.AddAttributeError(
+            path.Root("restful"),
+            "Incomplete Restful Module Configuration",
+            "The restful module endpoint is set, but its username or api_key is missing. "+
+                "Set them in the restful block or use the CEPH_RESTFUL_USERNAME and CEPH_RESTFUL_API_KEY environment variables.",
+        )
+        return nil, diags
+    }
+
+    return restful, diags
+}
+
+// restfulNotConfiguredDetail returns the detail of the error raised when a
+// feature needs the mgr restful module and the provider has no restful
+// configuration. The Dashboard API has no equivalent for the mon commands
+// these features run.
+func restfulNotConfiguredDetail(feature string) string {
+    return feature + " through the mgr restful module, as the Dashboard API does not expose the mon commands needed. " +
+        "Enable it with `ceph mgr module enable restful`, create an API key with `ceph restful create-key <user>` " +
+        "and set the restful block of the provider or the CEPH_RESTFUL_* environment variables. " +
+        "The module is deprecated and disabled by default in current Ceph releases."
+}
@@ -1,10 +1,16 @@
 package provider
 
 import (
+    "context"
     "testing"
 
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
     "github.com/hashicorp/terraform-plugin-framework/providerserver"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
     "github.com/hashicorp/terraform-plugin-go/tfprotov6"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during acceptance testing
@@ -16,3 +22,52 @@ func testAccPreCheck(t *testing.T) {
     // You can add checks here to ensure test environment is ready
     // For example: check if CEPH_ENDPOINT environment variable is set
 }
+
+// testResourceValue returns the schema of a resource and a value of it with
+// the given attributes set and all others null, for building configs, plans
+// and states in unit tests
+func testResourceValue(t *testing.T, r resource.Resource, values map[string]tftypes.Value) (schema.Schema, tftypes.Value) {
+    t.Helper()
+    ctx := context.Background()
+    
+    var resp resource.SchemaResponse
+    r.Schema(ctx, resource.SchemaRequest{}, &resp)
+    objectType := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+    
+    attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+    for name, attrType := range objectType.AttributeTypes {
+        attrs[name] = tftypes.NewValue(attrType, nil)
+    }
+    for name, v := range values {
+        if _, ok := attrs[name]; !ok {
+            t.Fatalf("Attribute %s is not part of the schema", name)
+        }
+        attrs[name] = v
+    }
+    
+    return resp.Schema, tftypes.NewValue(objectType, attrs)
+}
+
+// testDataSourceValue returns the schema of a data source and a value of it
+// with the given attributes set and all others null, like testResourceValue
+func testDataSourceValue(t *testing.T, d datasource.DataSource, values map[string]tftypes.Value) (dsschema.Schema, tftypes.Value) {
+    t.Helper()
+    ctx := context.Background()
+    
+    var resp datasource.SchemaResponse
+    d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+    objectType := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+    
+    attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+    for name, attrType := range objectType.AttributeTypes {
+        attrs[name] = tftypes.NewValue(attrType, nil)
+    }
+    for name, v := range values {
+        if _, ok := attrs[name]; !ok {
+            t.Fatalf("Attribute %s is not part of the schema", name)
+        }
+        attrs[name] = v
+    }
+    
+    return resp.Schema, tftypes.NewValue(objectType, attrs)
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Defaults for new CRUSH rules, matching `ceph osd crush rule create-replicated`
+const (
+    defaultCrushRoot          = "default"
+    defaultCrushFailureDomain = "host"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                   = &crushRuleResource{}
+    _ resource.ResourceWithConfigure      = &crushRuleResource{}
+    _ resource.ResourceWithImportState    = &crushRuleResource{}
+    _ resource.ResourceWithValidateConfig = &crushRuleResource{}
+)
+
+// NewCrushRuleResource is a helper function to simplify the provider implementation
+func NewCrushRuleResource() resource.Resource {
+    return &crushRuleResource{}
+}
+
+// crushRuleResource is the resource implementation
+type crushRuleResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *crushRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_crush_rule"
+}
+
+// Schema defines the schema for the resource
+func (r *crushRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a Ceph CRUSH rule. CRUSH rules cannot be modified, so any change replaces the rule.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "CRUSH rule identifier",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the CRUSH rule",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "rule_id": schema.Int64Attribute{
+                Description: "Numeric id of the CRUSH rule",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                },
+            },
+            "type": schema.StringAttribute{
+                Description: "Type of the rule (replicated or erasure). The Ceph API can only create replicated rules; " +
+                    "erasure rules are created by Ceph together with the erasure coded pool that references them.",
+                Optional: true,
+                Computed: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.OneOf(poolTypeReplicated, poolTypeErasure),
+                },
+            },
+            "root": schema.StringAttribute{
+                Description: "Name of the CRUSH bucket the placement starts from. Defaults to default.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "failure_domain": schema.StringAttribute{
+                Description: "CRUSH bucket type replicas are spread across, e.g. host or rack. Defaults to host.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "device_class": schema.StringAttribute{
+                Description: "CRUSH device class to place data on, e.g. hdd or ssd",
+                Optional:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+        },
+    }
+}
+
+// ValidateConfig rejects rule types the Ceph API cannot create
+func (r *crushRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    var config CrushRuleResourceModel
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if config.Type.ValueString() == poolTypeErasure {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("type"),
+            "Unsupported CRUSH Rule Type",
+            "The Ceph API can only create replicated CRUSH rules. Erasure rules are created by Ceph from the erasure code profile "+
+                "when an erasure coded pool is created; set crush_rule on the ceph_pool to name the rule instead.",
+        )
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *crushRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *crushRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan CrushRuleResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := plan.Name.ValueString()
+    ruleReq := CrushRuleCreateRequest{
+        Name:          name,
+        Root:          defaultCrushRoot,
+        FailureDomain: defaultCrushFailureDomain,
+    }
+
+    if isKnown(plan.Root) {
+        ruleReq.Root = plan.Root.ValueString()
+    }
+    if isKnown(plan.FailureDomain) {
+        ruleReq.FailureDomain = plan.FailureDomain.ValueString()
+    }
+    if isKnown(plan.DeviceClass) {
+        ruleReq.DeviceClass = plan.DeviceClass.ValueString()
+    }
+
+    err := r.client.CreateCrushRule(ctx, ruleReq)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph CRUSH Rule",
+            fmt.Sprintf("Could not create CRUSH rule %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    rule, err := r.client.GetCrushRule(ctx, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph CRUSH Rule",
+            fmt.Sprintf("Could not read created CRUSH rule %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    plan.setCrushRule(rule)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *crushRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state CrushRuleResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := state.Name.ValueString()
+    rule, err := r.client.GetCrushRule(ctx, name)
+    if err != nil {
+        // If the rule is not found, remove it from state
+        if errors.Is(err, errCrushRuleNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph CRUSH Rule",
+            fmt.Sprintf("Could not read CRUSH rule %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    state.setCrushRule(rule)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement
+func (r *crushRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "CRUSH Rules Cannot Be Updated",
+        "CRUSH rules are immutable and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *crushRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state CrushRuleResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := state.Name.ValueString()
+    err := r.client.DeleteCrushRule(ctx, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph CRUSH Rule",
+            fmt.Sprintf("Could not delete CRUSH rule %s: %s", name, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *crushRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Use the ID (rule name) as the import identifier
+    resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// erasureCodePlugins are the erasure code plugins shipped with Ceph
+var erasureCodePlugins = []string{"jerasure", "isa", "lrc", "shec", "clay"}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &erasureCodeProfileResource{}
+    _ resource.ResourceWithConfigure   = &erasureCodeProfileResource{}
+    _ resource.ResourceWithImportState = &erasureCodeProfileResource{}
+)
+
+// NewErasureCodeProfileResource is a helper function to simplify the provider implementation
+func NewErasureCodeProfileResource() resource.Resource {
+    return &erasureCodeProfileResource{}
+}
+
+// erasureCodeProfileResource is the resource implementation
+type erasureCodeProfileResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *erasureCodeProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_erasure_code_profile"
+}
+
+// Schema defines the schema for the resource
+func (r *erasureCodeProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    // Ceph does not allow modifying a profile that may be in use by a pool,
+    // so every attribute forces a new profile
+    resp.Schema = schema.Schema{
+        Description: "Manages a Ceph erasure code profile. Profiles are immutable, so any change replaces the profile. A profile cannot be destroyed while pools use it.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Erasure code profile identifier",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the erasure code profile",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "k": schema.Int64Attribute{
+                Description: "Number of data chunks",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                    int64planmodifier.RequiresReplace(),
+                },
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(2),
+                },
+            },
+            "m": schema.Int64Attribute{
+                Description: "Number of coding chunks, i.e. the number of OSDs that may fail without losing data",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                    int64planmodifier.RequiresReplace(),
+                },
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(1),
+                },
+            },
+            "plugin": schema.StringAttribute{
+                Description: "Erasure code plugin (jerasure, isa, lrc, shec or clay)",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.OneOf(erasureCodePlugins...),
+                },
+            },
+            "technique": schema.StringAttribute{
+                Description: "Coding technique of the plugin, e.g. reed_sol_van for jerasure",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "crush_failure_domain": schema.StringAttribute{
+                Description: "CRUSH bucket type across which chunks are spread, e.g. host or rack",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "crush_device_class": schema.StringAttribute{
+                Description: "CRUSH device class to place chunks on, e.g. hdd or ssd",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "crush_root": schema.StringAttribute{
+                Description: "Name of the CRUSH bucket used as the root of the placement",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *erasureCodeProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *erasureCodeProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan ErasureCodeProfileResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := plan.Name.ValueString()
+    profile := ErasureCodeProfile{Name: name}
+
+    if isKnown(plan.K) {
+        profile.K = flexInt64(plan.K.ValueInt64())
+    }
+    if isKnown(plan.M) {
+        profile.M = flexInt64(plan.M.ValueInt64())
+    }
+    if isKnown(plan.Plugin) {
+        profile.Plugin = plan.Plugin.ValueString()
+    }
+    if isKnown(plan.Technique) {
+        profile.Technique = plan.Technique.ValueString()
+    }
+    if isKnown(plan.CrushFailureDomain) {
+        profile.CrushFailureDomain = plan.CrushFailureDomain.ValueString()
+    }
+    if isKnown(plan.CrushDeviceClass) {
+        profile.CrushDeviceClass = plan.CrushDeviceClass.ValueString()
+    }
+    if isKnown(plan.CrushRoot) {
+        profile.CrushRoot = plan.CrushRoot.ValueString()
+    }
+
+    err := r.client.CreateErasureCodeProfile(ctx, profile)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not create erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    // Read back the defaults filled in by Ceph
+    created, err := r.client.GetErasureCodeProfile(ctx, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not read created erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    plan.setErasureCodeProfile(created)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *erasureCodeProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state ErasureCodeProfileResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := state.Name.ValueString()
+    profile, err := r.client.GetErasureCodeProfile(ctx, name)
+    if err != nil {
+        // If the profile is not found, remove it from state
+        if errors.Is(err, errErasureCodeProfileNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not read erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    state.setErasureCodeProfile(profile)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement
+func (r *erasureCodeProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "Erasure Code Profiles Cannot Be Updated",
+        "Erasure code profiles are immutable and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *erasureCodeProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state ErasureCodeProfileResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    name := state.Name.ValueString()
+
+    // Pools managed in the same configuration are destroyed first, as they
+    // depend on the profile; any pool left using it blocks the deletion
+    pools, err := r.client.ListPools(ctx, false)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not list the pools using erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+
+    var users []string
+    for _, pool := range pools {
+        if pool.ErasureCodeProfile == name {
+            users = append(users, pool.Name)
+        }
+    }
+    if len(users) > 0 {
+        resp.Diagnostics.AddError(
+            "Erasure Code Profile In Use",
+            fmt.Sprintf("Cannot delete erasure code profile %s while pools use it: %s. Remove the pools first.", name, strings.Join(users, ", ")),
+        )
+        return
+    }
+
+    err = r.client.DeleteErasureCodeProfile(ctx, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph Erasure Code Profile",
+            fmt.Sprintf("Could not delete erasure code profile %s: %s", name, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *erasureCodeProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Use the ID (profile name) as the import identifier
+    resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
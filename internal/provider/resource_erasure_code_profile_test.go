@@ -0,0 +1,129 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for erasure code profile resource
+func TestAccErasureCodeProfileResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestErasureCodeProfileResourceCreate tests that only the configured
+// values are sent and that the defaults filled in by Ceph are read back
+func TestErasureCodeProfileResourceCreate(t *testing.T) {
+    var created map[string]interface{}
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.Method + " " + r.URL.Path {
+        case "POST /api/erasure_code_profile":
+            json.NewDecoder(r.Body).Decode(&created)
+            w.WriteHeader(http.StatusCreated)
+        case "GET /api/erasure_code_profile/ec42":
+            w.Write([]byte(`{"name": "ec42", "k": "4", "m": "2", "plugin": "isa", "technique": "reed_sol_van", "crush-failure-domain": "host", "crush-root": "default"}`))
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    r := &erasureCodeProfileResource{client: newTestRetryClient(server)}
+    s, plan := testResourceValue(t, r, map[string]tftypes.Value{
+        "name":                 tftypes.NewValue(tftypes.String, "ec42"),
+        "k":                    tftypes.NewValue(tftypes.Number, 4),
+        "m":                    tftypes.NewValue(tftypes.Number, 2),
+        "plugin":               tftypes.NewValue(tftypes.String, "isa"),
+        "technique":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+        "crush_failure_domain": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+    })
+    
+    req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: plan}}
+    resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(plan.Type(), nil)}}
+    r.Create(context.Background(), req, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    
+    want := map[string]interface{}{"name": "ec42", "k": float64(4), "m": float64(2), "plugin": "isa"}
+    if !reflect.DeepEqual(created, want) {
+        t.Errorf("Expected profile %v to be created, got %v", want, created)
+    }
+    
+    var state ErasureCodeProfileResourceModel
+    resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+    if state.K.ValueInt64() != 4 || state.M.ValueInt64() != 2 || state.Technique.ValueString() != "reed_sol_van" ||
+        state.CrushFailureDomain.ValueString() != "host" || !state.CrushDeviceClass.IsNull() {
+        t.Errorf("Unexpected state: %+v", state)
+    }
+}
+
+// TestErasureCodeProfileResourceDelete tests that a profile still used by a
+// pool is not deleted
+func TestErasureCodeProfileResourceDelete(t *testing.T) {
+    tests := map[string]struct {
+        pools      []map[string]interface{}
+        wantDelete bool
+        wantError  string
+    }{
+        "unused": {
+            pools:      []map[string]interface{}{{"pool_name": "rbd", "type": "replicated"}},
+            wantDelete: true,
+        },
+        "in use": {
+            pools: []map[string]interface{}{
+                {"pool_name": "rbd", "type": "replicated"},
+                {"pool_name": "data", "type": "erasure", "erasure_code_profile": "ec42"},
+            },
+            wantError: "Erasure Code Profile In Use",
+        },
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            deleted := false
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                switch r.Method + " " + r.URL.Path {
+                case "GET /api/pool":
+                    json.NewEncoder(w).Encode(tt.pools)
+                case "DELETE /api/erasure_code_profile/ec42":
+                    deleted = true
+                    w.WriteHeader(http.StatusNoContent)
+                default:
+                    t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+                    w.WriteHeader(http.StatusNotFound)
+                }
+            }))
+            defer server.Close()
+            
+            r := &erasureCodeProfileResource{client: newTestRetryClient(server)}
+            s, raw := testResourceValue(t, r, map[string]tftypes.Value{
+                "id":   tftypes.NewValue(tftypes.String, "ec42"),
+                "name": tftypes.NewValue(tftypes.String, "ec42"),
+            })
+            state := tfsdk.State{Schema: s, Raw: raw}
+            
+            resp := resource.DeleteResponse{State: state}
+            r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+            
+            if deleted != tt.wantDelete {
+                t.Errorf("Expected delete %t, got %t", tt.wantDelete, deleted)
+            }
+            if tt.wantError == "" && resp.Diagnostics.HasError() {
+                t.Errorf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            if tt.wantError != "" && (resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tt.wantError) {
+                t.Errorf("Expected error %q, got %v", tt.wantError, resp.Diagnostics)
+            }
+        })
+    }
+}
@@ -2,22 +2,46 @@ package provider
 
 import (
     "context"
+    "errors"
     "fmt"
+    "slices"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
 
+    "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+    "github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
     "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
     "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Default timeouts for pool operations that wait on Dashboard background tasks
+const (
+    defaultPoolCreateTimeout = 20 * time.Minute
+    defaultPoolUpdateTimeout = 20 * time.Minute
+    defaultPoolDeleteTimeout = 10 * time.Minute
+)
+
 // Ensure the implementation satisfies the expected interfaces
 var (
-    _ resource.Resource                = &poolResource{}
-    _ resource.ResourceWithConfigure   = &poolResource{}
-    _ resource.ResourceWithImportState = &poolResource{}
+    _ resource.Resource                   = &poolResource{}
+    _ resource.ResourceWithConfigure      = &poolResource{}
+    _ resource.ResourceWithImportState    = &poolResource{}
+    _ resource.ResourceWithValidateConfig = &poolResource{}
+    _ resource.ResourceWithModifyPlan     = &poolResource{}
 )
 
 // NewPoolResource is a helper function to simplify the provider implementation
@@ -36,43 +60,176 @@ func (r *poolResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource
-func (r *poolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *poolResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
     resp.Schema = schema.Schema{
         Description: "Manages a Ceph pool.",
         Attributes: map[string]schema.Attribute{
             "id": schema.StringAttribute{
-                Description: "Pool identifier",
+                Description: "Numeric pool id, which is kept when the pool is renamed",
                 Computed:    true,
                 PlanModifiers: []planmodifier.String{
                     stringplanmodifier.UseStateForUnknown(),
                 },
             },
             "name": schema.StringAttribute{
-                Description: "Name of the pool",
+                Description: "Name of the pool. Changing it renames the pool in place.",
                 Required:    true,
+            },
+            "pool_type": schema.StringAttribute{
+                Description: "Type of the pool (replicated or erasure)",
+                Optional:    true,
+                Computed:    true,
                 PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
                     stringplanmodifier.RequiresReplace(),
                 },
+                Validators: []validator.String{
+                    stringvalidator.OneOf(poolTypeReplicated, poolTypeErasure),
+                },
             },
-            "pool_type": schema.StringAttribute{
-                Description: "Type of the pool (replicated or erasure)",
+            "erasure_code_profile": schema.StringAttribute{
+                Description: "Erasure code profile of an erasure coded pool. Defaults to the cluster's default profile. Cannot be changed after creation.",
                 Optional:    true,
                 Computed:    true,
                 PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
                     stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "crush_rule": schema.StringAttribute{
+                Description: "Name of the CRUSH rule placing the pool's data. Defaults to the cluster's default rule for the pool type. Can be changed in place, which migrates the data. Conflicts with crush_device_class.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
                     stringplanmodifier.UseStateForUnknown(),
                 },
             },
+            "crush_device_class": schema.StringAttribute{
+                Description: "Device class, such as ssd or hdd, to place the data of a replicated pool on. The pool uses the replicated CRUSH rule \"replicated_<class>\", which is created below the default root with host failure domain if missing. Can be changed in place, which migrates the data. Removing the attribute keeps the pool on its current rule. Conflicts with crush_rule.",
+                Optional:    true,
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                    stringvalidator.ConflictsWith(path.MatchRoot("crush_rule")),
+                },
+            },
+            "quota_max_bytes": schema.StringAttribute{
+                Description: "Maximum number of bytes stored in the pool, either in bytes or with a unit such as \"500GiB\" or \"2TB\". Removing the attribute clears the quota.",
+                Optional:    true,
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "quota_max_objects": schema.Int64Attribute{
+                Description: "Maximum number of objects stored in the pool. Removing the attribute clears the quota.",
+                Optional:    true,
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(0),
+                },
+            },
+            "allow_ec_overwrites": schema.BoolAttribute{
+                Description: "Allow partial overwrites on an erasure coded pool, as required for RBD and CephFS data. Cannot be disabled once enabled.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Bool{
+                    boolplanmodifier.UseStateForUnknown(),
+                    boolplanmodifier.RequiresReplaceIf(
+                        func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+                            resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+                        },
+                        "Disabling EC overwrites requires replacing the pool.",
+                        "Disabling EC overwrites requires replacing the pool.",
+                    ),
+                },
+            },
+            "pg_autoscale_mode": schema.StringAttribute{
+                Description: "PG autoscaler mode of the pool (on, warn or off). Defaults to the cluster's osd_pool_default_pg_autoscale_mode. While the autoscaler is on, pg_num and pgp_num are managed by Ceph and only reported.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.OneOf(pgAutoscaleModeOn, pgAutoscaleModeWarn, pgAutoscaleModeOff),
+                },
+            },
+            "target_size_bytes": schema.StringAttribute{
+                Description: "Expected size of the pool used by the PG autoscaler, either in bytes or with a unit such as \"1TiB\". Conflicts with target_size_ratio.",
+                Optional:    true,
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                    stringvalidator.ConflictsWith(path.MatchRoot("target_size_ratio")),
+                },
+            },
+            "target_size_ratio": schema.Float64Attribute{
+                Description: "Expected share of the cluster's capacity used by the pool, relative to the target ratios of other pools. Used by the PG autoscaler. Conflicts with target_size_bytes.",
+                Optional:    true,
+                Validators: []validator.Float64{
+                    float64validator.AtLeast(0),
+                    float64validator.ConflictsWith(path.MatchRoot("target_size_bytes")),
+                },
+            },
+            "pg_num_min": schema.Int64Attribute{
+                Description: "Minimum number of placement groups the PG autoscaler may choose.",
+                Optional:    true,
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(0),
+                },
+            },
+            "pg_num_max": schema.Int64Attribute{
+                Description: "Maximum number of placement groups the PG autoscaler may choose.",
+                Optional:    true,
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(0),
+                },
+            },
+            "compression_mode": schema.StringAttribute{
+                Description: "BlueStore compression mode of the pool (none, passive, aggressive or force). Defaults to the OSDs' bluestore_compression_mode. Removing the attribute also resets the other compression settings to the OSD defaults.",
+                Optional:    true,
+                Validators: []validator.String{
+                    stringvalidator.OneOf(compressionModes...),
+                },
+            },
+            "compression_algorithm": schema.StringAttribute{
+                Description: "BlueStore compression algorithm of the pool (snappy, zlib, zstd or lz4). Defaults to the OSDs' bluestore_compression_algorithm.",
+                Optional:    true,
+                Validators: []validator.String{
+                    stringvalidator.OneOf(compressionAlgorithms...),
+                },
+            },
+            "compression_required_ratio": schema.Float64Attribute{
+                Description: "Ratio of the compressed to the original size a chunk must reach to be stored compressed.",
+                Optional:    true,
+                Validators: []validator.Float64{
+                    float64validator.Between(0, 1),
+                },
+            },
+            "compression_min_blob_size": schema.StringAttribute{
+                Description: "Chunks smaller than this are never compressed, either in bytes or with a unit such as \"128KiB\".",
+                Optional:    true,
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "compression_max_blob_size": schema.StringAttribute{
+                Description: "Chunks larger than this are split before being compressed, either in bytes or with a unit such as \"512KiB\".",
+                Optional:    true,
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
             "pg_num": schema.Int64Attribute{
-                Description: "Number of placement groups",
+                Description: "Number of placement groups, which must be a power of two. Cannot be set while pg_autoscale_mode is \"on\".",
                 Optional:    true,
                 Computed:    true,
                 PlanModifiers: []planmodifier.Int64{
                     int64planmodifier.UseStateForUnknown(),
                 },
+                Validators: []validator.Int64{
+                    powerOfTwoValidator{},
+                },
             },
             "pgp_num": schema.Int64Attribute{
-                Description: "Number of placement groups for placement",
+                Description: "Number of placement groups for placement. Cannot be set while pg_autoscale_mode is \"on\".",
                 Optional:    true,
                 Computed:    true,
                 PlanModifiers: []planmodifier.Int64{
@@ -80,22 +237,304 @@ func (r *poolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                 },
             },
             "size": schema.Int64Attribute{
-                Description: "Replication size",
+                Description: "Replication size. Not configurable for erasure coded pools, whose size is k+m of the erasure code profile.",
                 Optional:    true,
                 Computed:    true,
                 PlanModifiers: []planmodifier.Int64{
                     int64planmodifier.UseStateForUnknown(),
                 },
             },
-            "application": schema.StringAttribute{
-                Description: "Pool application (rbd, cephfs, rgw)",
+            "min_size": schema.Int64Attribute{
+                Description: "Minimum number of replicas, or chunks of an erasure coded pool, that must be available for the pool to accept I/O. Defaults to a value chosen by Ceph from size.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(1),
+                },
+            },
+            "allow_size_one": schema.BoolAttribute{
+                Description: "Acknowledge that a size of 1 keeps a single copy of the data, which is lost with any failing OSD. Required to set size to 1; the cluster must also allow it through mon_allow_pool_size_one.",
+                Optional:    true,
+            },
+            "deletion_protection": schema.BoolAttribute{
+                Description: "Refuse to destroy or replace the pool, which deletes all its data. Must be disabled and applied before the pool can be deleted.",
+                Optional:    true,
+            },
+            "applications": schema.SetAttribute{
+                Description: "Applications enabled on the pool, such as rbd, cephfs or rgw. Applications missing from the set are disabled.",
+                ElementType: types.StringType,
                 Optional:    true,
                 Computed:    true,
+                PlanModifiers: []planmodifier.Set{
+                    setplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.Set{
+                    setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+                    setvalidator.ConflictsWith(path.MatchRoot("application")),
+                },
+            },
+            "application": schema.StringAttribute{
+                Description:        "Pool application (rbd, cephfs, rgw)",
+                DeprecationMessage: "Use applications instead. application enables a single application without disabling others.",
+                Optional:           true,
+                Computed:           true,
                 PlanModifiers: []planmodifier.String{
                     stringplanmodifier.UseStateForUnknown(),
                 },
             },
+            "application_metadata": schema.MapAttribute{
+                Description: "Metadata keys of the enabled applications, e.g. { cephfs = { data = \"myfs\" } }. Only the keys listed are managed, keys set by Ceph or by hand are left alone. Requires the restful block of the provider.",
+                ElementType: types.MapType{ElemType: types.StringType},
+                Optional:    true,
+            },
         },
+        Blocks: map[string]schema.Block{
+            "timeouts": timeouts.Block(ctx, timeouts.Opts{
+                Create: true,
+                Update: true,
+                Delete: true,
+            }),
+        },
+    }
+}
+
+// ValidateConfig rejects attributes that do not apply to the pool type
+func (r *poolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    var config PoolResourceModel
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // The autoscaler owns pg_num and pgp_num while it is on
+    if config.PgAutoscaleMode.ValueString() == pgAutoscaleModeOn {
+        for attr, v := range map[string]types.Int64{"pg_num": config.PgNum, "pgp_num": config.PgpNum} {
+            if !v.IsNull() {
+                resp.Diagnostics.AddAttributeError(
+                    path.Root(attr),
+                    "Conflicting PG Autoscaler Configuration",
+                    fmt.Sprintf("%s is managed by the PG autoscaler while pg_autoscale_mode is \"on\". Remove %s, guide the autoscaler with pg_num_min, pg_num_max or target_size_*, or set pg_autoscale_mode to \"warn\" or \"off\".", attr, attr),
+                )
+            }
+        }
+    }
+
+    if isKnown(config.PgNumMin) && isKnown(config.PgNumMax) && config.PgNumMax.ValueInt64() != 0 &&
+        config.PgNumMin.ValueInt64() > config.PgNumMax.ValueInt64() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("pg_num_min"),
+            "Invalid PG Autoscaler Bounds",
+            fmt.Sprintf("pg_num_min (%d) must not be greater than pg_num_max (%d).", config.PgNumMin.ValueInt64(), config.PgNumMax.ValueInt64()),
+        )
+    }
+
+    r.validateSize(config, &resp.Diagnostics)
+
+    // Application metadata goes through the optional mgr restful module.
+    // The client is only known once the provider is configured.
+    if !config.ApplicationMetadata.IsNull() && r.client != nil && r.client.Restful == nil {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("application_metadata"),
+            "Ceph Restful Module Not Configured",
+            restfulNotConfiguredDetail("Application metadata is managed"),
+        )
+    }
+
+    // Nothing to check until the pool type is known
+    if config.PoolType.IsUnknown() {
+        return
+    }
+
+    if config.PoolType.ValueString() == poolTypeErasure {
+        if !config.Size.IsNull() {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("size"),
+                "Invalid Attribute for Erasure Coded Pool",
+                "The size of an erasure coded pool is determined by k+m of its erasure code profile and cannot be set.",
+            )
+        }
+        if !config.CrushDeviceClass.IsNull() {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("crush_device_class"),
+                "Invalid Attribute for Erasure Coded Pool",
+                "The device class of an erasure coded pool is set by crush-device-class of its erasure code profile.",
+            )
+        }
+        return
+    }
+
+    if !config.ErasureCodeProfile.IsNull() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("erasure_code_profile"),
+            "Invalid Attribute for Replicated Pool",
+            "erasure_code_profile can only be set when pool_type is \"erasure\".",
+        )
+    }
+
+    if config.AllowEcOverwrites.ValueBool() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("allow_ec_overwrites"),
+            "Invalid Attribute for Replicated Pool",
+            "allow_ec_overwrites can only be enabled when pool_type is \"erasure\".",
+        )
+    }
+}
+
+// validateSize rejects unsafe replication settings and warns about risky ones
+func (r *poolResource) validateSize(config PoolResourceModel, diags *diag.Diagnostics) {
+    if !isKnown(config.Size) {
+        return
+    }
+    size := config.Size.ValueInt64()
+
+    if size == 1 && !config.AllowSizeOne.ValueBool() {
+        diags.AddAttributeError(
+            path.Root("size"),
+            "Unsafe Pool Size",
+            "A size of 1 keeps a single copy of the data, which is lost when that OSD fails. Set allow_size_one = true to acknowledge this.",
+        )
+    }
+
+    if size == 2 {
+        diags.AddAttributeWarning(
+            path.Root("size"),
+            "Risky Pool Size",
+            "A size of 2 leaves a single copy of the data while an OSD is down or recovering. A size of 3 is recommended for replicated pools.",
+        )
+    }
+
+    if !isKnown(config.MinSize) {
+        return
+    }
+    minSize := config.MinSize.ValueInt64()
+
+    if minSize > size {
+        diags.AddAttributeError(
+            path.Root("min_size"),
+            "Invalid Pool Min Size",
+            fmt.Sprintf("min_size (%d) must not be greater than size (%d), as the pool could never accept I/O.", minSize, size),
+        )
+    } else if minSize == 1 && size > 1 {
+        diags.AddAttributeWarning(
+            path.Root("min_size"),
+            "Risky Pool Min Size",
+            "A min_size of 1 lets the pool accept writes with a single copy available, which can lose data acknowledged to clients if that OSD fails too.",
+        )
+    }
+}
+
+// ModifyPlan refuses to destroy or replace pools with deletion protection,
+// warns when a configured pg_num is likely to be changed by the PG autoscaler,
+// which would show up as a difference on every plan, plans the CRUSH rule of
+// a configured device class and marks values Ceph derives from other changes
+// as unknown
+func (r *poolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    var config, plan, state PoolResourceModel
+
+    // Refuse to plan the destruction of a protected pool
+    if req.Plan.Raw.IsNull() {
+        resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+        if state.DeletionProtection.ValueBool() {
+            resp.Diagnostics.AddError(
+                "Pool Deletion Protected",
+                fmt.Sprintf("Pool %s has deletion_protection enabled and cannot be destroyed. Set deletion_protection = false and apply before destroying the pool.", state.Name.ValueString()),
+            )
+        }
+        return
+    }
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // An explicit "on" is rejected by ValidateConfig, so only the mode
+    // inherited from the cluster default is of interest here
+    if config.PgAutoscaleMode.IsNull() && !config.PgNum.IsNull() &&
+        (plan.PgAutoscaleMode.IsUnknown() || plan.PgAutoscaleMode.ValueString() == pgAutoscaleModeOn) {
+        resp.Diagnostics.AddAttributeWarning(
+            path.Root("pg_num"),
+            "PG Autoscaler May Change pg_num",
+            "pg_num is set while pg_autoscale_mode is not configured, and the pool may use the cluster's default \"on\" mode. "+
+                "The autoscaler would then change pg_num and Terraform would keep reverting it. "+
+                "Set pg_autoscale_mode to \"warn\" or \"off\", or remove pg_num.",
+        )
+    }
+
+    r.checkApplicationMetadata(ctx, plan, &resp.Diagnostics)
+
+    // A device class selects its replicated rule, which is created on apply
+    // if missing
+    if isKnown(config.CrushDeviceClass) {
+        plan.CrushRule = types.StringValue(deviceClassCrushRuleName(config.CrushDeviceClass.ValueString()))
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("crush_rule"), plan.CrushRule)...)
+    } else if config.CrushDeviceClass.IsUnknown() {
+        plan.CrushRule = types.StringUnknown()
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("crush_rule"), plan.CrushRule)...)
+    }
+
+    // Nothing else to plan for a new pool
+    if req.State.Raw.IsNull() {
+        return
+    }
+
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Removing application_metadata removes the managed keys, which also
+    // goes through the restful module
+    if plan.ApplicationMetadata.IsNull() && !state.ApplicationMetadata.IsNull() && r.client != nil && r.client.Restful == nil {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("application_metadata"),
+            "Ceph Restful Module Not Configured",
+            restfulNotConfiguredDetail("Removing application metadata removes its keys"),
+        )
+    }
+
+    // Refuse to replace a protected pool, which would delete its data. The
+    // attribute plan modifiers do not share their replacement decisions, so
+    // the attributes that require replacement are compared here.
+    if state.DeletionProtection.ValueBool() {
+        var replaced []string
+        if isKnown(plan.PoolType) && !plan.PoolType.Equal(state.PoolType) {
+            replaced = append(replaced, "pool_type")
+        }
+        if isKnown(plan.ErasureCodeProfile) && !plan.ErasureCodeProfile.Equal(state.ErasureCodeProfile) {
+            replaced = append(replaced, "erasure_code_profile")
+        }
+        if isKnown(plan.AllowEcOverwrites) && !plan.AllowEcOverwrites.ValueBool() && state.AllowEcOverwrites.ValueBool() {
+            replaced = append(replaced, "allow_ec_overwrites")
+        }
+
+        if len(replaced) > 0 {
+            resp.Diagnostics.AddError(
+                "Pool Deletion Protected",
+                fmt.Sprintf("Pool %s has deletion_protection enabled, but changing %s requires replacing it, which deletes all its data. Set deletion_protection = false and apply before making these changes.", state.Name.ValueString(), strings.Join(replaced, ", ")),
+            )
+            return
+        }
+    }
+
+    // Ceph may adjust min_size when size changes
+    if config.MinSize.IsNull() && !plan.Size.Equal(state.Size) {
+        plan.MinSize = types.Int64Unknown()
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("min_size"), plan.MinSize)...)
+    }
+
+    // applications and the deprecated application attribute follow each
+    // other when only one of them is configured
+    if config.Applications.IsNull() && !plan.Application.Equal(state.Application) {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("applications"), types.SetUnknown(types.StringType))...)
+    }
+    if config.Application.IsNull() && !plan.Applications.Equal(state.Applications) {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("application"), types.StringUnknown())...)
     }
 }
 
@@ -127,44 +566,132 @@ func (r *poolResource) Create(ctx context.Context, req resource.CreateRequest, r
         return
     }
 
+    createTimeout, diags := plan.Timeouts.Create(ctx, defaultPoolCreateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, createTimeout)
+    defer cancel()
+
     // Create the pool
     poolName := plan.Name.ValueString()
-    poolType := "replicated"
-    if !plan.PoolType.IsNull() {
+    poolType := poolTypeReplicated
+    if isKnown(plan.PoolType) {
         poolType = plan.PoolType.ValueString()
     }
 
+    // The Dashboard requires an initial pg_num, which the autoscaler is free
+    // to change later on
     pgNum := int64(32)
-    if !plan.PgNum.IsNull() {
+    if isKnown(plan.PgNum) {
         pgNum = plan.PgNum.ValueInt64()
+    } else if plan.PgNumMin.ValueInt64() > pgNum {
+        pgNum = plan.PgNumMin.ValueInt64()
+    } else if plan.PgNumMax.ValueInt64() > 0 && plan.PgNumMax.ValueInt64() < pgNum {
+        pgNum = plan.PgNumMax.ValueInt64()
     }
-    
+
     pgpNum := pgNum
-    if !plan.PgpNum.IsNull() {
+    if isKnown(plan.PgpNum) {
         pgpNum = plan.PgpNum.ValueInt64()
     }
 
-    size := int64(3)
-    if !plan.Size.IsNull() {
-        size = plan.Size.ValueInt64()
+    var applications []string
+    if isKnown(plan.Applications) {
+        resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &applications, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
     }
-
-    application := ""
-    if !plan.Application.IsNull() {
-        application = plan.Application.ValueString()
+    if isKnown(plan.Application) && !slices.Contains(applications, plan.Application.ValueString()) {
+        applications = append(applications, plan.Application.ValueString())
     }
 
     // Create pool request
     poolReq := PoolCreateRequest{
-        Pool:        poolName,
-        PoolType:    poolType,
-        PgNum:       int(pgNum),
-        PgpNum:      int(pgpNum),
-        Size:        int(size),
-        Application: application,
+        Pool:         poolName,
+        PoolType:     poolType,
+        PgNum:        int(pgNum),
+        PgpNum:       int(pgpNum),
+        Applications: applications,
+    }
+
+    if isKnown(plan.CrushDeviceClass) {
+        rule, err := r.client.EnsureDeviceClassCrushRule(ctx, plan.CrushDeviceClass.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Creating Ceph Pool",
+                fmt.Sprintf("Could not prepare the CRUSH rule of device class %s: %s", plan.CrushDeviceClass.ValueString(), err.Error()),
+            )
+            return
+        }
+        poolReq.RuleName = rule
+    } else if isKnown(plan.CrushRule) {
+        poolReq.RuleName = plan.CrushRule.ValueString()
     }
 
-    err := r.client.CreatePool(poolReq)
+    if isKnown(plan.QuotaMaxBytes) {
+        // Already checked by the schema validator
+        poolReq.QuotaMaxBytes, _ = parseByteSize(plan.QuotaMaxBytes.ValueString())
+    }
+    if isKnown(plan.QuotaMaxObjects) {
+        poolReq.QuotaMaxObjects = plan.QuotaMaxObjects.ValueInt64()
+    }
+
+    if isKnown(plan.PgAutoscaleMode) {
+        poolReq.PgAutoscaleMode = plan.PgAutoscaleMode.ValueString()
+    }
+    if isKnown(plan.TargetSizeBytes) {
+        poolReq.TargetSizeBytes, _ = parseByteSize(plan.TargetSizeBytes.ValueString())
+    }
+    if isKnown(plan.TargetSizeRatio) {
+        poolReq.TargetSizeRatio = plan.TargetSizeRatio.ValueFloat64()
+    }
+    if isKnown(plan.PgNumMin) {
+        poolReq.PgNumMin = plan.PgNumMin.ValueInt64()
+    }
+    if isKnown(plan.PgNumMax) {
+        poolReq.PgNumMax = plan.PgNumMax.ValueInt64()
+    }
+
+    if isKnown(plan.CompressionMode) {
+        poolReq.CompressionMode = plan.CompressionMode.ValueString()
+    }
+    if isKnown(plan.CompressionAlgorithm) {
+        poolReq.CompressionAlgorithm = plan.CompressionAlgorithm.ValueString()
+    }
+    if isKnown(plan.CompressionRequiredRatio) {
+        poolReq.CompressionRequiredRatio = plan.CompressionRequiredRatio.ValueFloat64()
+    }
+    if isKnown(plan.CompressionMinBlobSize) {
+        poolReq.CompressionMinBlobSize, _ = parseByteSize(plan.CompressionMinBlobSize.ValueString())
+    }
+    if isKnown(plan.CompressionMaxBlobSize) {
+        poolReq.CompressionMaxBlobSize, _ = parseByteSize(plan.CompressionMaxBlobSize.ValueString())
+    }
+
+    if poolType == poolTypeErasure {
+        // The size of an erasure coded pool is k+m of its profile
+        if isKnown(plan.ErasureCodeProfile) {
+            poolReq.ErasureCodeProfile = plan.ErasureCodeProfile.ValueString()
+        }
+        if isKnown(plan.AllowEcOverwrites) && plan.AllowEcOverwrites.ValueBool() {
+            poolReq.Flags = []string{poolFlagEcOverwrites}
+        }
+    } else {
+        poolReq.Size = 3
+        if isKnown(plan.Size) {
+            poolReq.Size = int(plan.Size.ValueInt64())
+        }
+    }
+
+    if isKnown(plan.MinSize) {
+        poolReq.MinSize = plan.MinSize.ValueInt64()
+    }
+
+    err := r.client.CreatePool(ctx, poolReq)
     if err != nil {
         resp.Diagnostics.AddError(
             "Error Creating Ceph Pool",
@@ -173,25 +700,25 @@ func (r *poolResource) Create(ctx context.Context, req resource.CreateRequest, r
         return
     }
 
-    // Set application if provided and not already set during pool creation
-    if application != "" {
-        err = r.client.SetApplication(poolName, application)
-        if err != nil {
-            resp.Diagnostics.AddWarning(
-                "Error Setting Pool Application",
-                fmt.Sprintf("Pool created but could not set application %s: %s", application, err.Error()),
-            )
-        }
+    // Read back the values chosen by Ceph, e.g. the size of an erasure
+    // coded pool or the default erasure code profile
+    pool, err := r.client.GetPool(ctx, poolName)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph Pool",
+            fmt.Sprintf("Could not read created pool %s: %s", poolName, err.Error()),
+        )
+        return
     }
 
     // Set the ID and computed values
-    plan.ID = types.StringValue(poolName)
-    plan.PoolType = types.StringValue(poolType)
-    plan.PgNum = types.Int64Value(pgNum)
-    plan.PgpNum = types.Int64Value(pgpNum)
-    plan.Size = types.Int64Value(size)
-    if application != "" {
-        plan.Application = types.StringValue(application)
+    plan.setPool(pool)
+
+    // The metadata is set last, so that the pool is kept in state if this
+    // fails and setting it is retried by the next apply
+    r.applyApplicationMetadata(ctx, poolName, plan.ApplicationMetadata, types.MapNull(applicationMetadataType), &resp.Diagnostics)
+    if resp.Diagnostics.HasError() {
+        plan.ApplicationMetadata = types.MapNull(applicationMetadataType)
     }
 
     // Save data into Terraform state
@@ -208,16 +735,24 @@ func (r *poolResource) Read(ctx context.Context, req resource.ReadRequest, resp
         return
     }
 
-    // Get pool information from Ceph
+    // Get pool information from Ceph, by id so that a pool renamed outside
+    // of Terraform is still found. States written before the id was numeric
+    // and imports by name fall back to the name.
     poolName := state.Name.ValueString()
-    poolData, err := r.client.GetPool(poolName)
+    var pool *Pool
+    var err error
+    if id, parseErr := strconv.ParseInt(state.ID.ValueString(), 10, 64); parseErr == nil {
+        pool, err = r.client.GetPoolByID(ctx, id)
+    } else {
+        pool, err = r.client.GetPool(ctx, poolName)
+    }
     if err != nil {
         // If the pool is not found, remove it from state
-        if err.Error() == "pool not found" {
+        if errors.Is(err, errPoolNotFound) {
             resp.State.RemoveResource(ctx)
             return
         }
-        
+
         resp.Diagnostics.AddError(
             "Error Reading Ceph Pool",
             fmt.Sprintf("Could not read pool %s: %s", poolName, err.Error()),
@@ -226,26 +761,11 @@ func (r *poolResource) Read(ctx context.Context, req resource.ReadRequest, resp
     }
 
     // Update the state with the latest data
-    if poolType, ok := poolData["type"].(string); ok {
-        state.PoolType = types.StringValue(poolType)
-    }
-
-    if pgNum, ok := poolData["pg_num"].(float64); ok {
-        state.PgNum = types.Int64Value(int64(pgNum))
-    }
-    
-    if pgpNum, ok := poolData["pgp_num"].(float64); ok {
-        state.PgpNum = types.Int64Value(int64(pgpNum))
-    }
-
-    if size, ok := poolData["size"].(float64); ok {
-        state.Size = types.Int64Value(int64(size))
-    }
-
-    if apps, ok := poolData["application_metadata"].(map[string]interface{}); ok {
-        for app := range apps {
-            state.Application = types.StringValue(app)
-            break
+    state.setPool(pool)
+    if !state.ApplicationMetadata.IsNull() {
+        r.readApplicationMetadata(ctx, pool.Name, &state, &resp.Diagnostics)
+        if resp.Diagnostics.HasError() {
+            return
         }
     }
 
@@ -265,13 +785,122 @@ func (r *poolResource) Update(ctx context.Context, req resource.UpdateRequest, r
         return
     }
 
+    updateTimeout, diags := plan.Timeouts.Update(ctx, defaultPoolUpdateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+    defer cancel()
+
     poolName := plan.Name.ValueString()
     changed := false
 
+    // Rename the pool first, the remaining changes address it by name
+    if plan.Name.ValueString() != state.Name.ValueString() {
+        // States written before the id was numeric and imports by name are
+        // resolved by name as in Read, so that the rename is verified on
+        // the right pool
+        id, parseErr := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+        if parseErr != nil {
+            pool, err := r.client.GetPool(ctx, state.Name.ValueString())
+            if err != nil {
+                resp.Diagnostics.AddError(
+                    "Error Renaming Ceph Pool",
+                    fmt.Sprintf("Could not read pool %s: %s", state.Name.ValueString(), err.Error()),
+                )
+                return
+            }
+            id = pool.ID
+        }
+
+        err := r.client.RenamePool(ctx, id, state.Name.ValueString(), poolName)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Renaming Ceph Pool",
+                fmt.Sprintf("Could not rename pool %s to %s: %s", state.Name.ValueString(), poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    // Update pg_autoscale_mode first, so that pg_num changes below are not
+    // undone by the autoscaler
+    if isKnown(plan.PgAutoscaleMode) && plan.PgAutoscaleMode.ValueString() != state.PgAutoscaleMode.ValueString() {
+        err := r.client.SetPoolProperty(ctx, poolName, "pg_autoscale_mode", plan.PgAutoscaleMode.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool PG Autoscale Mode",
+                fmt.Sprintf("Could not update pg_autoscale_mode for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    // Update the autoscaler hints if changed, removed hints are cleared by
+    // setting them to 0
+    var planTargetBytes, stateTargetBytes int64
+    if isKnown(plan.TargetSizeBytes) {
+        planTargetBytes, _ = parseByteSize(plan.TargetSizeBytes.ValueString())
+    }
+    if isKnown(state.TargetSizeBytes) {
+        stateTargetBytes, _ = parseByteSize(state.TargetSizeBytes.ValueString())
+    }
+    if planTargetBytes != stateTargetBytes {
+        err := r.client.SetPoolProperty(ctx, poolName, "target_size_bytes", planTargetBytes)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Target Size",
+                fmt.Sprintf("Could not update target_size_bytes for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    if plan.TargetSizeRatio.ValueFloat64() != state.TargetSizeRatio.ValueFloat64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "target_size_ratio", plan.TargetSizeRatio.ValueFloat64())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Target Size",
+                fmt.Sprintf("Could not update target_size_ratio for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    if plan.PgNumMin.ValueInt64() != state.PgNumMin.ValueInt64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "pg_num_min", plan.PgNumMin.ValueInt64())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool PG Num Bounds",
+                fmt.Sprintf("Could not update pg_num_min for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    if plan.PgNumMax.ValueInt64() != state.PgNumMax.ValueInt64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "pg_num_max", plan.PgNumMax.ValueInt64())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool PG Num Bounds",
+                fmt.Sprintf("Could not update pg_num_max for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
     // Update pg_num if changed
     if !plan.PgNum.IsNull() && plan.PgNum.ValueInt64() != state.PgNum.ValueInt64() {
         pgNum := int(plan.PgNum.ValueInt64())
-        err := r.client.SetPoolProperty(poolName, "pg_num", pgNum)
+        err := r.client.SetPoolProperty(ctx, poolName, "pg_num", pgNum)
         if err != nil {
             resp.Diagnostics.AddError(
                 "Error Updating Pool PG Num",
@@ -281,11 +910,11 @@ func (r *poolResource) Update(ctx context.Context, req resource.UpdateRequest, r
         }
         changed = true
     }
-    
+
     // Update pgp_num if changed
     if !plan.PgpNum.IsNull() && plan.PgpNum.ValueInt64() != state.PgpNum.ValueInt64() {
         pgpNum := int(plan.PgpNum.ValueInt64())
-        err := r.client.SetPoolProperty(poolName, "pgp_num", pgpNum)
+        err := r.client.SetPoolProperty(ctx, poolName, "pgp_num", pgpNum)
         if err != nil {
             resp.Diagnostics.AddError(
                 "Error Updating Pool PGP Num",
@@ -296,10 +925,24 @@ func (r *poolResource) Update(ctx context.Context, req resource.UpdateRequest, r
         changed = true
     }
 
+    // Lower min_size before changing size and raise it afterwards, so that
+    // it never exceeds size in between
+    if isKnown(plan.MinSize) && plan.MinSize.ValueInt64() < state.MinSize.ValueInt64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "min_size", plan.MinSize.ValueInt64())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Min Size",
+                fmt.Sprintf("Could not update min_size for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
     // Update size if changed
     if !plan.Size.IsNull() && plan.Size.ValueInt64() != state.Size.ValueInt64() {
         size := int(plan.Size.ValueInt64())
-        err := r.client.SetPoolProperty(poolName, "size", size)
+        err := r.client.SetPoolProperty(ctx, poolName, "size", size)
         if err != nil {
             resp.Diagnostics.AddError(
                 "Error Updating Pool Size",
@@ -310,55 +953,202 @@ func (r *poolResource) Update(ctx context.Context, req resource.UpdateRequest, r
         changed = true
     }
 
-    // Update application if changed
-    if !plan.Application.IsNull() && 
-       (state.Application.IsNull() || plan.Application.ValueString() != state.Application.ValueString()) {
-        application := plan.Application.ValueString()
-        err := r.client.SetApplication(poolName, application)
+    if isKnown(plan.MinSize) && plan.MinSize.ValueInt64() > state.MinSize.ValueInt64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "min_size", plan.MinSize.ValueInt64())
         if err != nil {
             resp.Diagnostics.AddError(
-                "Error Updating Pool Application",
-                fmt.Sprintf("Could not update application for pool %s: %s", poolName, err.Error()),
+                "Error Updating Pool Min Size",
+                fmt.Sprintf("Could not update min_size for pool %s: %s", poolName, err.Error()),
             )
             return
         }
         changed = true
     }
 
-    // If something changed, refresh the state
-    if changed {
-        poolData, err := r.client.GetPool(poolName)
+    // Update crush_rule if changed, creating the rule of a newly configured
+    // device class first
+    if isKnown(plan.CrushRule) && plan.CrushRule.ValueString() != state.CrushRule.ValueString() {
+        if isKnown(plan.CrushDeviceClass) {
+            _, err := r.client.EnsureDeviceClassCrushRule(ctx, plan.CrushDeviceClass.ValueString())
+            if err != nil {
+                resp.Diagnostics.AddError(
+                    "Error Updating Pool CRUSH Rule",
+                    fmt.Sprintf("Could not prepare the CRUSH rule of device class %s: %s", plan.CrushDeviceClass.ValueString(), err.Error()),
+                )
+                return
+            }
+        }
+
+        err := r.client.SetPoolProperty(ctx, poolName, "crush_rule", plan.CrushRule.ValueString())
         if err != nil {
             resp.Diagnostics.AddError(
-                "Error Reading Updated Ceph Pool",
-                fmt.Sprintf("Could not read updated pool %s: %s", poolName, err.Error()),
+                "Error Updating Pool CRUSH Rule",
+                fmt.Sprintf("Could not update crush_rule for pool %s: %s", poolName, err.Error()),
             )
             return
         }
-        
-        // Update the state with the latest data
-        if poolType, ok := poolData["type"].(string); ok {
-            plan.PoolType = types.StringValue(poolType)
+        changed = true
+    }
+
+    // Update quotas if changed, a removed quota is cleared by setting it to 0
+    var planMaxBytes, stateMaxBytes int64
+    if isKnown(plan.QuotaMaxBytes) {
+        planMaxBytes, _ = parseByteSize(plan.QuotaMaxBytes.ValueString())
+    }
+    if isKnown(state.QuotaMaxBytes) {
+        stateMaxBytes, _ = parseByteSize(state.QuotaMaxBytes.ValueString())
+    }
+    if planMaxBytes != stateMaxBytes {
+        err := r.client.SetPoolProperty(ctx, poolName, "quota_max_bytes", planMaxBytes)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Quota",
+                fmt.Sprintf("Could not update quota_max_bytes for pool %s: %s", poolName, err.Error()),
+            )
+            return
         }
+        changed = true
+    }
 
-        if pgNum, ok := poolData["pg_num"].(float64); ok {
-            plan.PgNum = types.Int64Value(int64(pgNum))
+    if plan.QuotaMaxObjects.ValueInt64() != state.QuotaMaxObjects.ValueInt64() {
+        err := r.client.SetPoolProperty(ctx, poolName, "quota_max_objects", plan.QuotaMaxObjects.ValueInt64())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Quota",
+                fmt.Sprintf("Could not update quota_max_objects for pool %s: %s", poolName, err.Error()),
+            )
+            return
         }
-        
-        if pgpNum, ok := poolData["pgp_num"].(float64); ok {
-            plan.PgpNum = types.Int64Value(int64(pgpNum))
+        changed = true
+    }
+
+    // Removing compression_mode makes the Dashboard reset all compression
+    // options, so those still configured are applied again below
+    if plan.CompressionMode.IsNull() && !state.CompressionMode.IsNull() {
+        err := r.client.SetPoolProperty(ctx, poolName, "compression_mode", poolOptionUnset)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Compression",
+                fmt.Sprintf("Could not unset compression_mode for pool %s: %s", poolName, err.Error()),
+            )
+            return
         }
+        state.CompressionAlgorithm = types.StringNull()
+        state.CompressionRequiredRatio = types.Float64Null()
+        state.CompressionMinBlobSize = types.StringNull()
+        state.CompressionMaxBlobSize = types.StringNull()
+        changed = true
+    }
 
-        if size, ok := poolData["size"].(float64); ok {
-            plan.Size = types.Int64Value(int64(size))
+    // Update the remaining compression options in one go, removed options
+    // are unset
+    compression := map[string]interface{}{}
+    if isKnown(plan.CompressionMode) && plan.CompressionMode.ValueString() != state.CompressionMode.ValueString() {
+        compression["compression_mode"] = plan.CompressionMode.ValueString()
+    }
+    if !plan.CompressionAlgorithm.Equal(state.CompressionAlgorithm) {
+        compression["compression_algorithm"] = poolOptionUnset
+        if isKnown(plan.CompressionAlgorithm) {
+            compression["compression_algorithm"] = plan.CompressionAlgorithm.ValueString()
+        }
+    }
+    if plan.CompressionRequiredRatio.ValueFloat64() != state.CompressionRequiredRatio.ValueFloat64() {
+        compression["compression_required_ratio"] = plan.CompressionRequiredRatio.ValueFloat64()
+    }
+    for option, values := range map[string][2]types.String{
+        "compression_min_blob_size": {plan.CompressionMinBlobSize, state.CompressionMinBlobSize},
+        "compression_max_blob_size": {plan.CompressionMaxBlobSize, state.CompressionMaxBlobSize},
+    } {
+        var planBytes, stateBytes int64
+        if isKnown(values[0]) {
+            planBytes, _ = parseByteSize(values[0].ValueString())
+        }
+        if isKnown(values[1]) {
+            stateBytes, _ = parseByteSize(values[1].ValueString())
+        }
+        if planBytes != stateBytes {
+            compression[option] = planBytes
         }
+    }
 
-        if apps, ok := poolData["application_metadata"].(map[string]interface{}); ok {
-            for app := range apps {
-                plan.Application = types.StringValue(app)
-                break
-            }
+    if len(compression) > 0 {
+        err := r.client.SetPoolProperties(ctx, poolName, compression)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Compression",
+                fmt.Sprintf("Could not update compression settings for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    // Enable overwrites on an erasure coded pool; they cannot be disabled
+    // again, which the plan turns into a replacement
+    if isKnown(plan.AllowEcOverwrites) && plan.AllowEcOverwrites.ValueBool() && !state.AllowEcOverwrites.ValueBool() {
+        err := r.client.SetPoolProperty(ctx, poolName, "flags", []string{poolFlagEcOverwrites})
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool EC Overwrites",
+                fmt.Sprintf("Could not enable allow_ec_overwrites for pool %s: %s", poolName, err.Error()),
+            )
+            return
         }
+        changed = true
+    }
+
+    // Update applications if changed. The deprecated application attribute
+    // only enables its application, leaving others enabled.
+    if isKnown(plan.Applications) && !plan.Applications.Equal(state.Applications) {
+        var applications []string
+        resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &applications, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+
+        err := r.client.SetApplications(ctx, poolName, applications)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Applications",
+                fmt.Sprintf("Could not update applications for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    } else if isKnown(plan.Application) && plan.Application.ValueString() != state.Application.ValueString() {
+        application := plan.Application.ValueString()
+        err := r.client.SetApplication(ctx, poolName, application)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Pool Application",
+                fmt.Sprintf("Could not update application for pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+        changed = true
+    }
+
+    // Set application metadata once its applications are enabled
+    if !plan.ApplicationMetadata.Equal(state.ApplicationMetadata) {
+        r.applyApplicationMetadata(ctx, poolName, plan.ApplicationMetadata, state.ApplicationMetadata, &resp.Diagnostics)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+    }
+
+    // If something changed, refresh the state
+    if changed {
+        pool, err := r.client.GetPool(ctx, poolName)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Reading Updated Ceph Pool",
+                fmt.Sprintf("Could not read updated pool %s: %s", poolName, err.Error()),
+            )
+            return
+        }
+
+        // Update the state with the latest data
+        plan.setPool(pool)
     }
 
     // Save updated data into Terraform state
@@ -375,9 +1165,26 @@ func (r *poolResource) Delete(ctx context.Context, req resource.DeleteRequest, r
         return
     }
 
+    deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultPoolDeleteTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+    defer cancel()
+
     // Delete the pool
     poolName := state.Name.ValueString()
-    err := r.client.DeletePool(poolName)
+    if state.DeletionProtection.ValueBool() {
+        resp.Diagnostics.AddError(
+            "Pool Deletion Protected",
+            fmt.Sprintf("Pool %s has deletion_protection enabled and cannot be deleted. Set deletion_protection = false and apply before deleting the pool.", poolName),
+        )
+        return
+    }
+
+    err := r.client.DeletePool(ctx, poolName)
     if err != nil {
         resp.Diagnostics.AddError(
             "Error Deleting Ceph Pool",
@@ -389,6 +1196,201 @@ func (r *poolResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 // ImportState imports the resource state
 func (r *poolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-    // Use the ID (pool name) as the import identifier
+    // Pools are imported by numeric id or by name, Read resolves the other
+    if _, err := strconv.ParseInt(req.ID, 10, 64); err == nil {
+        resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+        return
+    }
     resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
-}
\ No newline at end of file
+}
+
+// applicationMetadataType is the type of the application_metadata attribute
+// values, the metadata keys of one application
+var applicationMetadataType = types.MapType{ElemType: types.StringType}
+
+// applicationMetadataKey is a metadata key of a pool application
+type applicationMetadataKey struct {
+    Application string
+    Key         string
+    Value       string
+}
+
+// checkApplicationMetadata rejects application metadata of applications
+// that are not enabled on the pool. ValidateConfig checks that the restful
+// module is configured.
+func (r *poolResource) checkApplicationMetadata(ctx context.Context, plan PoolResourceModel, diags *diag.Diagnostics) {
+    if plan.ApplicationMetadata.IsNull() {
+        return
+    }
+
+    // The applications enabled by the deprecated application attribute
+    // alone are only known once applied
+    if !isKnown(plan.ApplicationMetadata) || !isKnown(plan.Applications) {
+        return
+    }
+
+    var metadata map[string]types.Map
+    var applications []string
+    diags.Append(plan.ApplicationMetadata.ElementsAs(ctx, &metadata, false)...)
+    diags.Append(plan.Applications.ElementsAs(ctx, &applications, false)...)
+    if diags.HasError() {
+        return
+    }
+
+    for app := range metadata {
+        if !slices.Contains(applications, app) && plan.Application.ValueString() != app {
+            diags.AddAttributeError(
+                path.Root("application_metadata").AtMapKey(app),
+                "Application Not Enabled",
+                fmt.Sprintf("Application %s has metadata but is not enabled on the pool. Add it to applications.", app),
+            )
+        }
+    }
+}
+
+// applyApplicationMetadata sets the planned application metadata keys and
+// removes those previously managed that are no longer planned
+func (r *poolResource) applyApplicationMetadata(ctx context.Context, poolName string, planned, previous types.Map, diags *diag.Diagnostics) {
+    var plannedMetadata, previousMetadata map[string]map[string]string
+    if !planned.IsNull() {
+        diags.Append(planned.ElementsAs(ctx, &plannedMetadata, false)...)
+    }
+    if !previous.IsNull() {
+        diags.Append(previous.ElementsAs(ctx, &previousMetadata, false)...)
+    }
+    if diags.HasError() || (len(plannedMetadata) == 0 && len(previousMetadata) == 0) {
+        return
+    }
+
+    // Rejected when planning, unless the provider configuration changed
+    if r.client.Restful == nil {
+        diags.AddAttributeError(
+            path.Root("application_metadata"),
+            "Ceph Restful Module Not Configured",
+            restfulNotConfiguredDetail("Application metadata is managed"),
+        )
+        return
+    }
+
+    // Only keys whose value on the pool differs are sent
+    current, err := r.client.GetApplicationMetadata(ctx, poolName)
+    if err != nil {
+        diags.AddError(
+            "Error Reading Pool Application Metadata",
+            fmt.Sprintf("Could not read application metadata of pool %s: %s", poolName, err.Error()),
+        )
+        return
+    }
+
+    set, remove := applicationMetadataChanges(plannedMetadata, previousMetadata, current)
+    for _, k := range remove {
+        err := r.client.RemoveApplicationMetadata(ctx, poolName, k.Application, k.Key)
+        if err != nil {
+            diags.AddError(
+                "Error Removing Pool Application Metadata",
+                fmt.Sprintf("Could not remove %s key %s of pool %s: %s", k.Application, k.Key, poolName, err.Error()),
+            )
+            return
+        }
+    }
+    for _, k := range set {
+        err := r.client.SetApplicationMetadata(ctx, poolName, k.Application, k.Key, k.Value)
+        if err != nil {
+            diags.AddError(
+                "Error Setting Pool Application Metadata",
+                fmt.Sprintf("Could not set %s key %s of pool %s: %s", k.Application, k.Key, poolName, err.Error()),
+            )
+            return
+        }
+    }
+}
+
+// readApplicationMetadata refreshes the managed application metadata keys
+// of the model from the pool
+func (r *poolResource) readApplicationMetadata(ctx context.Context, poolName string, m *PoolResourceModel, diags *diag.Diagnostics) {
+    if r.client.Restful == nil {
+        diags.AddAttributeWarning(
+            path.Root("application_metadata"),
+            "Pool Application Metadata Not Refreshed",
+            "The mgr restful module is not configured, so the application metadata of the pool could not be read and was kept as is.",
+        )
+        return
+    }
+
+    var managed map[string]map[string]string
+    diags.Append(m.ApplicationMetadata.ElementsAs(ctx, &managed, false)...)
+    if diags.HasError() {
+        return
+    }
+
+    current, err := r.client.GetApplicationMetadata(ctx, poolName)
+    if err != nil {
+        diags.AddError(
+            "Error Reading Pool Application Metadata",
+            fmt.Sprintf("Could not read application metadata of pool %s: %s", poolName, err.Error()),
+        )
+        return
+    }
+
+    value, d := types.MapValueFrom(ctx, applicationMetadataType, managedApplicationMetadata(managed, current))
+    diags.Append(d...)
+    m.ApplicationMetadata = value
+}
+
+// applicationMetadataChanges returns the keys to set and to remove to go from
+// the current metadata of a pool to the planned one, in a stable order. Keys
+// that were not previously managed are never removed.
+func applicationMetadataChanges(planned, previous, current map[string]map[string]string) (set, remove []applicationMetadataKey) {
+    for app, keys := range planned {
+        for key, value := range keys {
+            if v, ok := current[app][key]; !ok || v != value {
+                set = append(set, applicationMetadataKey{Application: app, Key: key, Value: value})
+            }
+        }
+    }
+
+    for app, keys := range previous {
+        for key := range keys {
+            if _, ok := planned[app][key]; ok {
+                continue
+            }
+            if _, ok := current[app][key]; ok {
+                remove = append(remove, applicationMetadataKey{Application: app, Key: key})
+            }
+        }
+    }
+
+    sortApplicationMetadataKeys(set)
+    sortApplicationMetadataKeys(remove)
+    return set, remove
+}
+
+// sortApplicationMetadataKeys sorts keys by application, then key
+func sortApplicationMetadataKeys(keys []applicationMetadataKey) {
+    sort.Slice(keys, func(i, j int) bool {
+        if keys[i].Application != keys[j].Application {
+            return keys[i].Application < keys[j].Application
+        }
+        return keys[i].Key < keys[j].Key
+    })
+}
+
+// managedApplicationMetadata returns the current values of the managed keys.
+// Keys removed from the pool drop out, as do applications no longer enabled.
+func managedApplicationMetadata(managed, current map[string]map[string]string) map[string]map[string]string {
+    refreshed := make(map[string]map[string]string, len(managed))
+    for app, keys := range managed {
+        currentKeys, enabled := current[app]
+        if !enabled {
+            continue
+        }
+
+        refreshed[app] = make(map[string]string, len(keys))
+        for key := range keys {
+            if value, ok := currentKeys[key]; ok {
+                refreshed[app][key] = value
+            }
+        }
+    }
+    return refreshed
+}
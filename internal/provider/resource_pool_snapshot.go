@@ -0,0 +1,297 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "regexp"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// poolSnapshotNameRegex matches pool snapshot names, which must not contain
+// the "/" separating them from the pool in IDs
+var poolSnapshotNameRegex = regexp.MustCompile(`^[^/]+$`)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &poolSnapshotResource{}
+    _ resource.ResourceWithConfigure   = &poolSnapshotResource{}
+    _ resource.ResourceWithModifyPlan  = &poolSnapshotResource{}
+    _ resource.ResourceWithImportState = &poolSnapshotResource{}
+)
+
+// NewPoolSnapshotResource is a helper function to simplify the provider implementation
+func NewPoolSnapshotResource() resource.Resource {
+    return &poolSnapshotResource{}
+}
+
+// poolSnapshotResource is the resource implementation
+type poolSnapshotResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *poolSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_pool_snapshot"
+}
+
+// Schema defines the schema for the resource
+func (r *poolSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a pool snapshot, as taken by `ceph osd pool mksnap`. Pools whose snapshots are managed by a client such as RBD cannot have pool snapshots. The Dashboard API can list pool snapshots but not take or remove them, so this resource requires the restful block of the provider and the deprecated mgr restful module, which is disabled by default. Without it, the snapshots of a pool can still be read with the ceph_pool data source.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Snapshot identifier, pool/snapshot",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool to snapshot",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the snapshot",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.RegexMatches(poolSnapshotNameRegex, "must not be empty or contain /"),
+                },
+            },
+            "snap_id": schema.Int64Attribute{
+                Description: "Snapshot id assigned by Ceph",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                },
+            },
+            "created": schema.StringAttribute{
+                Description: "Time the snapshot was taken",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *poolSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// ModifyPlan rejects new snapshots of pools that cannot have pool snapshots,
+// and snapshots to create or destroy without restful module, before
+// anything is applied
+func (r *poolSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    // All attributes require replacement, so there are no in-place updates
+    // to check, and nothing can be checked before the provider is configured
+    if (!req.Plan.Raw.IsNull() && !req.State.Raw.IsNull()) || r.client == nil {
+        return
+    }
+
+    if r.client.Restful == nil {
+        resp.Diagnostics.AddError(
+            "Ceph Restful Module Not Configured",
+            restfulNotConfiguredDetail("Pool snapshots are taken and removed"),
+        )
+        return
+    }
+
+    // Nothing more to check when the snapshot is destroyed
+    if req.Plan.Raw.IsNull() {
+        return
+    }
+
+    var plan PoolSnapshotResourceModel
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // A pool created in the same apply is new and has no snapshot mode yet
+    if !isKnown(plan.Pool) {
+        return
+    }
+    r.checkSnapshotMode(ctx, plan.Pool.ValueString(), &resp.Diagnostics)
+}
+
+// checkSnapshotMode adds an error if the pool is in self-managed snapshot
+// mode. A missing pool is left to the later steps.
+func (r *poolSnapshotResource) checkSnapshotMode(ctx context.Context, poolName string, diags *diag.Diagnostics) {
+    pool, err := r.client.GetPool(ctx, poolName)
+    if errors.Is(err, errPoolNotFound) {
+        return
+    }
+    if err != nil {
+        diags.AddError(
+            "Error Reading Ceph Pool",
+            fmt.Sprintf("Could not read pool %s: %s", poolName, err.Error()),
+        )
+        return
+    }
+
+    if pool.HasFlag(poolFlagSelfManagedSnaps) {
+        diags.AddAttributeError(
+            path.Root("pool"),
+            "Pool Uses Self-Managed Snapshots",
+            fmt.Sprintf("Pool %s is in self-managed snapshot mode, e.g. because RBD or CephFS snapshots were taken in it, and cannot have pool snapshots. Use RBD or CephFS snapshots instead.", poolName),
+        )
+    }
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *poolSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan PoolSnapshotResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := plan.Pool.ValueString()
+    name := plan.Name.ValueString()
+    id := poolSnapshotID(pool, name)
+
+    // The pool may have changed mode since the plan, or only been created
+    r.checkSnapshotMode(ctx, pool, &resp.Diagnostics)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    err := r.client.CreatePoolSnapshot(ctx, pool, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph Pool Snapshot",
+            fmt.Sprintf("Could not create pool snapshot %s: %s", id, err.Error()),
+        )
+        return
+    }
+
+    snapshot, err := r.client.GetPoolSnapshot(ctx, pool, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph Pool Snapshot",
+            fmt.Sprintf("Could not read pool snapshot %s: %s", id, err.Error()),
+        )
+        return
+    }
+
+    plan.setPoolSnapshot(pool, snapshot)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *poolSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state PoolSnapshotResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    snapshot, err := r.client.GetPoolSnapshot(ctx, pool, state.Name.ValueString())
+    if err != nil {
+        // If the snapshot is not found, remove it from state
+        if errors.Is(err, errPoolSnapshotNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph Pool Snapshot",
+            fmt.Sprintf("Could not read pool snapshot %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+
+    state.setPoolSnapshot(pool, snapshot)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement
+func (r *poolSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "Pool Snapshots Cannot Be Updated",
+        "Pool snapshots are immutable and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *poolSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state PoolSnapshotResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Nothing to remove once the snapshot or its pool is gone
+    pool := state.Pool.ValueString()
+    name := state.Name.ValueString()
+    if _, err := r.client.GetPoolSnapshot(ctx, pool, name); errors.Is(err, errPoolSnapshotNotFound) {
+        return
+    }
+
+    err := r.client.DeletePoolSnapshot(ctx, pool, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph Pool Snapshot",
+            fmt.Sprintf("Could not delete pool snapshot %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *poolSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    pool, name, err := parsePoolSnapshotID(req.ID)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import pool snapshot: %s", err.Error()),
+        )
+        return
+    }
+
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool"), pool)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
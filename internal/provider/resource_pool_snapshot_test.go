@@ -0,0 +1,87 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestPoolSnapshotResourceModifyPlan tests that snapshots of pools in
+// self-managed snapshot mode are rejected at plan time
+func TestPoolSnapshotResourceModifyPlan(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.URL.Path {
+        case "/api/pool/rbd":
+            json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "rbd", "flags_names": "hashpspool,selfmanaged_snaps"})
+        case "/api/pool/data":
+            json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "data", "flags_names": "hashpspool"})
+        default:
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    client.Restful = &RestfulConfig{Endpoint: server.URL, Username: "admin", APIKey: "key"}
+    r := &poolSnapshotResource{client: client}
+    
+    tests := map[string]int{
+        "rbd":  1,
+        "data": 0,
+        "new":  0,
+    }
+    
+    for pool, wantErrors := range tests {
+        s, plan := testResourceValue(t, r, map[string]tftypes.Value{
+            "pool": tftypes.NewValue(tftypes.String, pool),
+            "name": tftypes.NewValue(tftypes.String, "base"),
+        })
+        _, state := testResourceValue(t, r, nil)
+        
+        req := resource.ModifyPlanRequest{
+            Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+            State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(state.Type(), nil)},
+        }
+        resp := resource.ModifyPlanResponse{Plan: req.Plan}
+        r.ModifyPlan(context.Background(), req, &resp)
+        
+        if got := resp.Diagnostics.ErrorsCount(); got != wantErrors {
+            t.Errorf("%s: expected %d errors, got %v", pool, wantErrors, resp.Diagnostics)
+        }
+    }
+    
+    // Without the restful module no snapshot can be taken at all
+    client.Restful = nil
+    s, plan := testResourceValue(t, r, map[string]tftypes.Value{
+        "pool": tftypes.NewValue(tftypes.String, "data"),
+        "name": tftypes.NewValue(tftypes.String, "base"),
+    })
+    req := resource.ModifyPlanRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+        State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(plan.Type(), nil)},
+    }
+    resp := resource.ModifyPlanResponse{Plan: req.Plan}
+    r.ModifyPlan(context.Background(), req, &resp)
+    
+    if !resp.Diagnostics.HasError() {
+        t.Error("Expected an error without restful module")
+    }
+    
+    // Nor removed
+    req = resource.ModifyPlanRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(plan.Type(), nil)},
+        State: tfsdk.State{Schema: s, Raw: plan},
+    }
+    resp = resource.ModifyPlanResponse{Plan: req.Plan}
+    r.ModifyPlan(context.Background(), req, &resp)
+    
+    if !resp.Diagnostics.HasError() {
+        t.Error("Expected an error destroying without restful module")
+    }
+}
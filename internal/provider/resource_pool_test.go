@@ -1,18 +1,494 @@
 package provider
 
 import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
     "testing"
 
-    "github.com/hashicorp/terraform-plugin-framework/providerserver"
-    "github.com/hashicorp/terraform-plugin-go/tfprotov6"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
-// testAccProtoV6ProviderFactories are used to instantiate a provider during acceptance testing
-var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-    "ceph": providerserver.NewProtocol6WithError(New("test")()),
+// validatePoolConfig runs the pool resource's ValidateConfig on the given attributes
+func validatePoolConfig(t *testing.T, values map[string]tftypes.Value) diag.Diagnostics {
+    r := &poolResource{}
+    s, raw := testResourceValue(t, r, values)
+    
+    var resp resource.ValidateConfigResponse
+    r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+        Config: tfsdk.Config{Schema: s, Raw: raw},
+    }, &resp)
+    return resp.Diagnostics
 }
 
-func testAccPreCheck(t *testing.T) {
-    // You can add checks here to ensure test environment is ready
-    // For example: check if CEPH_ENDPOINT environment variable is set
+// TestPoolResourceValidateConfig tests that attributes that do not apply to
+// the pool type and unsafe replication settings are rejected
+func TestPoolResourceValidateConfig(t *testing.T) {
+    tests := []struct {
+        name     string
+        values   map[string]tftypes.Value
+        errors   []string
+        warnings []string
+    }{
+        {
+            name: "replicated",
+            values: map[string]tftypes.Value{
+                "name":      tftypes.NewValue(tftypes.String, "rbd"),
+                "pool_type": tftypes.NewValue(tftypes.String, "replicated"),
+                "size":      tftypes.NewValue(tftypes.Number, 3),
+                "min_size":  tftypes.NewValue(tftypes.Number, 2),
+            },
+        },
+        {
+            name: "erasure with size",
+            values: map[string]tftypes.Value{
+                "name":      tftypes.NewValue(tftypes.String, "data"),
+                "pool_type": tftypes.NewValue(tftypes.String, "erasure"),
+                "size":      tftypes.NewValue(tftypes.Number, 3),
+            },
+            errors: []string{"size"},
+        },
+        {
+            name: "replicated with erasure settings",
+            values: map[string]tftypes.Value{
+                "name":                 tftypes.NewValue(tftypes.String, "rbd"),
+                "pool_type":            tftypes.NewValue(tftypes.String, "replicated"),
+                "erasure_code_profile": tftypes.NewValue(tftypes.String, "default"),
+                "allow_ec_overwrites":  tftypes.NewValue(tftypes.Bool, true),
+            },
+            errors: []string{"erasure_code_profile", "allow_ec_overwrites"},
+        },
+        {
+            name: "erasure with device class",
+            values: map[string]tftypes.Value{
+                "name":               tftypes.NewValue(tftypes.String, "data"),
+                "pool_type":          tftypes.NewValue(tftypes.String, "erasure"),
+                "crush_device_class": tftypes.NewValue(tftypes.String, "ssd"),
+            },
+            errors: []string{"crush_device_class"},
+        },
+        {
+            name: "pg_num with autoscaler on",
+            values: map[string]tftypes.Value{
+                "name":              tftypes.NewValue(tftypes.String, "rbd"),
+                "pg_autoscale_mode": tftypes.NewValue(tftypes.String, "on"),
+                "pg_num":            tftypes.NewValue(tftypes.Number, 32),
+            },
+            errors: []string{"pg_num"},
+        },
+        {
+            name: "inverted autoscaler bounds",
+            values: map[string]tftypes.Value{
+                "name":       tftypes.NewValue(tftypes.String, "rbd"),
+                "pg_num_min": tftypes.NewValue(tftypes.Number, 64),
+                "pg_num_max": tftypes.NewValue(tftypes.Number, 32),
+            },
+            errors: []string{"pg_num_min"},
+        },
+        {
+            name: "size one",
+            values: map[string]tftypes.Value{
+                "name": tftypes.NewValue(tftypes.String, "scratch"),
+                "size": tftypes.NewValue(tftypes.Number, 1),
+            },
+            errors: []string{"size"},
+        },
+        {
+            name: "size one allowed",
+            values: map[string]tftypes.Value{
+                "name":           tftypes.NewValue(tftypes.String, "scratch"),
+                "size":           tftypes.NewValue(tftypes.Number, 1),
+                "allow_size_one": tftypes.NewValue(tftypes.Bool, true),
+            },
+        },
+        {
+            name: "size two",
+            values: map[string]tftypes.Value{
+                "name": tftypes.NewValue(tftypes.String, "rbd"),
+                "size": tftypes.NewValue(tftypes.Number, 2),
+            },
+            warnings: []string{"size"},
+        },
+        {
+            name: "min_size above size",
+            values: map[string]tftypes.Value{
+                "name":     tftypes.NewValue(tftypes.String, "rbd"),
+                "size":     tftypes.NewValue(tftypes.Number, 3),
+                "min_size": tftypes.NewValue(tftypes.Number, 4),
+            },
+            errors: []string{"min_size"},
+        },
+        {
+            name: "min_size one",
+            values: map[string]tftypes.Value{
+                "name":     tftypes.NewValue(tftypes.String, "rbd"),
+                "size":     tftypes.NewValue(tftypes.Number, 3),
+                "min_size": tftypes.NewValue(tftypes.Number, 1),
+            },
+            warnings: []string{"min_size"},
+        },
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            diags := validatePoolConfig(t, tt.values)
+            checkDiagnosticPaths(t, "error", diags.Errors(), tt.errors)
+            checkDiagnosticPaths(t, "warning", diags.Warnings(), tt.warnings)
+        })
+    }
+}
+
+// checkDiagnosticPaths checks that diags are reported for exactly the given attributes
+func checkDiagnosticPaths(t *testing.T, kind string, diags diag.Diagnostics, attrs []string) {
+    t.Helper()
+    
+    if len(diags) != len(attrs) {
+        t.Fatalf("Expected %d %ss, got %v", len(attrs), kind, diags)
+    }
+    for _, attr := range attrs {
+        found := false
+        for _, d := range diags {
+            if withPath, ok := d.(diag.DiagnosticWithPath); ok && withPath.Path().Equal(path.Root(attr)) {
+                found = true
+            }
+        }
+        if !found {
+            t.Errorf("Expected an %s for %s, got %v", kind, attr, diags)
+        }
+    }
+}
+
+// TestApplicationMetadataChanges tests that only differing keys are set and
+// only previously managed keys are removed
+func TestApplicationMetadataChanges(t *testing.T) {
+    planned := map[string]map[string]string{
+        "cephfs": {"data": "myfs", "metadata": "myfs"},
+    }
+    previous := map[string]map[string]string{
+        "cephfs": {"data": "oldfs", "metadata": "myfs", "owner": "team-a"},
+        "rgw":    {"zone": "default"},
+    }
+    current := map[string]map[string]string{
+        "cephfs": {"data": "oldfs", "metadata": "myfs", "owner": "team-a", "manual": "yes"},
+    }
+    
+    set, remove := applicationMetadataChanges(planned, previous, current)
+    
+    wantSet := []applicationMetadataKey{{Application: "cephfs", Key: "data", Value: "myfs"}}
+    if !reflect.DeepEqual(set, wantSet) {
+        t.Errorf("Expected to set %v, got %v", wantSet, set)
+    }
+    
+    // rgw is no longer enabled, so its key is already gone
+    wantRemove := []applicationMetadataKey{{Application: "cephfs", Key: "owner"}}
+    if !reflect.DeepEqual(remove, wantRemove) {
+        t.Errorf("Expected to remove %v, got %v", wantRemove, remove)
+    }
+}
+
+// TestManagedApplicationMetadata tests that Read only reports managed keys
+func TestManagedApplicationMetadata(t *testing.T) {
+    managed := map[string]map[string]string{
+        "cephfs": {"data": "myfs", "owner": "team-a"},
+        "rgw":    {"zone": "default"},
+    }
+    current := map[string]map[string]string{
+        "cephfs": {"data": "otherfs", "metadata": "otherfs"},
+        "rbd":    {},
+    }
+    
+    got := managedApplicationMetadata(managed, current)
+    want := map[string]map[string]string{
+        "cephfs": {"data": "otherfs"},
+    }
+    if !reflect.DeepEqual(got, want) {
+        t.Errorf("Expected %v, got %v", want, got)
+    }
+}
+
+// TestPoolResourceValidateConfigApplicationMetadata tests that application
+// metadata is rejected when validating if the restful module is not
+// configured
+func TestPoolResourceValidateConfigApplicationMetadata(t *testing.T) {
+    tests := map[string]struct {
+        client *CephClient
+        errors []string
+    }{
+        "restful":      {client: &CephClient{Restful: &RestfulConfig{Endpoint: "https://localhost:8003", Username: "admin", APIKey: "key"}}},
+        "no restful":   {client: &CephClient{}, errors: []string{"application_metadata"}},
+        "unconfigured": {},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            r := &poolResource{client: tt.client}
+            s, raw := testResourceValue(t, r, map[string]tftypes.Value{
+                "name":                 tftypes.NewValue(tftypes.String, "fs_data"),
+                "application_metadata": testApplicationMetadata("cephfs"),
+            })
+            
+            var resp resource.ValidateConfigResponse
+            r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+                Config: tfsdk.Config{Schema: s, Raw: raw},
+            }, &resp)
+            
+            checkDiagnosticPaths(t, "error", resp.Diagnostics.Errors(), tt.errors)
+        })
+    }
+}
+
+// testApplicationMetadata returns application_metadata with a key of app
+func testApplicationMetadata(app string) tftypes.Value {
+    return tftypes.NewValue(tftypes.Map{ElementType: tftypes.Map{ElementType: tftypes.String}}, map[string]tftypes.Value{
+        app: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+            "data": tftypes.NewValue(tftypes.String, "myfs"),
+        }),
+    })
+}
+
+// TestPoolResourceModifyPlanApplicationMetadata tests that metadata of
+// applications not enabled is rejected, as is removing metadata without
+// restful module
+func TestPoolResourceModifyPlanApplicationMetadata(t *testing.T) {
+    restful := &RestfulConfig{Endpoint: "https://localhost:8003", Username: "admin", APIKey: "key"}
+    
+    tests := []struct {
+        name       string
+        restful    *RestfulConfig
+        planned    tftypes.Value
+        previous   tftypes.Value
+        wantErrors int
+    }{
+        {name: "enabled", restful: restful, planned: testApplicationMetadata("cephfs")},
+        {name: "not enabled", restful: restful, planned: testApplicationMetadata("rgw"), wantErrors: 1},
+        {name: "removed", restful: restful, previous: testApplicationMetadata("cephfs")},
+        {name: "removed without restful", previous: testApplicationMetadata("cephfs"), wantErrors: 1},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            client := NewCephClient("https://localhost:8443", "admin", "password")
+            client.Restful = tt.restful
+            r := &poolResource{client: client}
+            
+            pool := func(metadata tftypes.Value) map[string]tftypes.Value {
+                values := map[string]tftypes.Value{
+                    "id":           tftypes.NewValue(tftypes.String, "3"),
+                    "name":         tftypes.NewValue(tftypes.String, "fs_data"),
+                    "applications": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "cephfs")}),
+                }
+                if metadata.Type() != nil {
+                    values["application_metadata"] = metadata
+                }
+                return values
+            }
+            s, plan := testResourceValue(t, r, pool(tt.planned))
+            _, state := testResourceValue(t, r, pool(tt.previous))
+            if tt.previous.Type() == nil {
+                state = tftypes.NewValue(state.Type(), nil)
+            }
+            
+            req := resource.ModifyPlanRequest{
+                Config: tfsdk.Config{Schema: s, Raw: plan},
+                Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+                State:  tfsdk.State{Schema: s, Raw: state},
+            }
+            resp := resource.ModifyPlanResponse{Plan: req.Plan}
+            r.ModifyPlan(context.Background(), req, &resp)
+            
+            if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+                t.Errorf("Expected %d errors, got %v", tt.wantErrors, resp.Diagnostics)
+            }
+        })
+    }
+}
+
+// TestPoolResourceUpdateRenameLegacyID tests that renaming a pool whose
+// state still has a name-based id verifies the rename on the pool found by
+// name rather than on pool 0
+func TestPoolResourceUpdateRenameLegacyID(t *testing.T) {
+    patches := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.Method + " " + r.URL.Path {
+        case "GET /api/pool/rbd":
+            json.NewEncoder(w).Encode(map[string]interface{}{"pool": 7, "pool_name": "rbd", "type": "replicated"})
+        case "PATCH /api/pool/rbd":
+            // The rename is applied, but the response is lost
+            patches++
+            w.WriteHeader(http.StatusServiceUnavailable)
+        case "GET /api/pool":
+            json.NewEncoder(w).Encode([]map[string]interface{}{
+                {"pool": 0, "pool_name": "other", "type": "replicated"},
+                {"pool": 7, "pool_name": "vms", "type": "replicated"},
+            })
+        case "GET /api/pool/vms":
+            json.NewEncoder(w).Encode(map[string]interface{}{"pool": 7, "pool_name": "vms", "type": "replicated"})
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    r := &poolResource{client: newTestRetryClient(server)}
+    values := map[string]tftypes.Value{
+        "id":   tftypes.NewValue(tftypes.String, "rbd"),
+        "name": tftypes.NewValue(tftypes.String, "rbd"),
+    }
+    s, state := testResourceValue(t, r, values)
+    values["name"] = tftypes.NewValue(tftypes.String, "vms")
+    _, plan := testResourceValue(t, r, values)
+    
+    req := resource.UpdateRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+        State: tfsdk.State{Schema: s, Raw: state},
+    }
+    resp := resource.UpdateResponse{State: req.State}
+    r.Update(context.Background(), req, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    if patches != 1 {
+        t.Errorf("Expected the rename to be sent once, got %d", patches)
+    }
+    
+    var id types.String
+    resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+    if id.ValueString() != "7" {
+        t.Errorf("Expected id 7, got %s", id)
+    }
+}
+
+// TestPoolResourceModifyPlanCrushDeviceClass tests that a configured device
+// class plans the pool onto the replicated rule of the class
+func TestPoolResourceModifyPlanCrushDeviceClass(t *testing.T) {
+    tests := []struct {
+        name        string
+        deviceClass tftypes.Value
+        previous    string
+        want        types.String
+    }{
+        {name: "new pool", deviceClass: tftypes.NewValue(tftypes.String, "ssd"), want: types.StringValue("replicated_ssd")},
+        {name: "changed", deviceClass: tftypes.NewValue(tftypes.String, "ssd"), previous: "replicated_rule", want: types.StringValue("replicated_ssd")},
+        {name: "unchanged", deviceClass: tftypes.NewValue(tftypes.String, "hdd"), previous: "replicated_hdd", want: types.StringValue("replicated_hdd")},
+        {name: "unknown", deviceClass: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), previous: "replicated_rule", want: types.StringUnknown()},
+        {name: "removed", deviceClass: tftypes.NewValue(tftypes.String, nil), previous: "replicated_ssd", want: types.StringValue("replicated_ssd")},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            r := &poolResource{}
+            s, config := testResourceValue(t, r, map[string]tftypes.Value{
+                "name":               tftypes.NewValue(tftypes.String, "rbd"),
+                "crush_device_class": tt.deviceClass,
+            })
+            
+            // crush_rule is planned from the state by UseStateForUnknown
+            planned := map[string]tftypes.Value{
+                "id":                 tftypes.NewValue(tftypes.String, "3"),
+                "name":               tftypes.NewValue(tftypes.String, "rbd"),
+                "crush_device_class": tt.deviceClass,
+                "crush_rule":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+            }
+            if tt.previous != "" {
+                planned["crush_rule"] = tftypes.NewValue(tftypes.String, tt.previous)
+            }
+            _, plan := testResourceValue(t, r, planned)
+            
+            state := tftypes.NewValue(plan.Type(), nil)
+            if tt.previous != "" {
+                _, state = testResourceValue(t, r, map[string]tftypes.Value{
+                    "id":         tftypes.NewValue(tftypes.String, "3"),
+                    "name":       tftypes.NewValue(tftypes.String, "rbd"),
+                    "crush_rule": tftypes.NewValue(tftypes.String, tt.previous),
+                })
+            }
+            
+            req := resource.ModifyPlanRequest{
+                Config: tfsdk.Config{Schema: s, Raw: config},
+                Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+                State:  tfsdk.State{Schema: s, Raw: state},
+            }
+            resp := resource.ModifyPlanResponse{Plan: req.Plan}
+            r.ModifyPlan(context.Background(), req, &resp)
+            
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            
+            var rule types.String
+            resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), path.Root("crush_rule"), &rule)...)
+            if !rule.Equal(tt.want) {
+                t.Errorf("Expected crush_rule %s, got %s", tt.want, rule)
+            }
+        })
+    }
+}
+
+// TestPoolResourceUpdateCrushDeviceClass tests that moving a pool to a device
+// class creates the rule of the class before switching the pool to it
+func TestPoolResourceUpdateCrushDeviceClass(t *testing.T) {
+    var requests []string
+    var createdRule, poolChanges map[string]interface{}
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        requests = append(requests, r.Method+" "+r.URL.Path)
+        switch r.Method + " " + r.URL.Path {
+        case "GET /api/crush_rule/replicated_ssd":
+            w.WriteHeader(http.StatusNotFound)
+        case "POST /api/crush_rule":
+            json.NewDecoder(r.Body).Decode(&createdRule)
+            w.WriteHeader(http.StatusCreated)
+        case "PATCH /api/pool/rbd":
+            json.NewDecoder(r.Body).Decode(&poolChanges)
+        case "GET /api/pool/rbd":
+            json.NewEncoder(w).Encode(map[string]interface{}{"pool": 3, "pool_name": "rbd", "type": "replicated", "crush_rule": "replicated_ssd"})
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+    defer server.Close()
+    
+    r := &poolResource{client: newTestRetryClient(server)}
+    values := map[string]tftypes.Value{
+        "id":         tftypes.NewValue(tftypes.String, "3"),
+        "name":       tftypes.NewValue(tftypes.String, "rbd"),
+        "pool_type":  tftypes.NewValue(tftypes.String, "replicated"),
+        "crush_rule": tftypes.NewValue(tftypes.String, "replicated_rule"),
+    }
+    s, state := testResourceValue(t, r, values)
+    values["crush_rule"] = tftypes.NewValue(tftypes.String, "replicated_ssd")
+    values["crush_device_class"] = tftypes.NewValue(tftypes.String, "ssd")
+    _, plan := testResourceValue(t, r, values)
+    
+    req := resource.UpdateRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+        State: tfsdk.State{Schema: s, Raw: state},
+    }
+    resp := resource.UpdateResponse{State: req.State}
+    r.Update(context.Background(), req, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    
+    wantRule := map[string]interface{}{"name": "replicated_ssd", "root": "default", "failure_domain": "host", "device_class": "ssd"}
+    if !reflect.DeepEqual(createdRule, wantRule) {
+        t.Errorf("Expected rule %v to be created, got %v", wantRule, createdRule)
+    }
+    if poolChanges["crush_rule"] != "replicated_ssd" {
+        t.Errorf("Expected the pool to be moved to replicated_ssd, got %v", poolChanges)
+    }
+    want := []string{"GET /api/crush_rule/replicated_ssd", "POST /api/crush_rule", "PATCH /api/pool/rbd", "GET /api/pool/rbd"}
+    if !reflect.DeepEqual(requests, want) {
+        t.Errorf("Expected requests %v, got %v", want, requests)
+    }
 }
@@ -0,0 +1,560 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+    "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts for RBD clone operations, which include flattening
+const (
+    defaultRBDCloneCreateTimeout = 20 * time.Minute
+    defaultRBDCloneUpdateTimeout = 20 * time.Minute
+    defaultRBDCloneDeleteTimeout = 20 * time.Minute
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdCloneResource{}
+    _ resource.ResourceWithConfigure   = &rbdCloneResource{}
+    _ resource.ResourceWithImportState = &rbdCloneResource{}
+    _ resource.ResourceWithModifyPlan  = &rbdCloneResource{}
+)
+
+// NewRBDCloneResource is a helper function to simplify the provider implementation
+func NewRBDCloneResource() resource.Resource {
+    return &rbdCloneResource{}
+}
+
+// rbdCloneResource is the resource implementation
+type rbdCloneResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdCloneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_clone"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdCloneResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a Ceph RBD image cloned from a snapshot.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Image spec of the clone, pool/namespace/image or pool/image outside of a namespace",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "parent_snapshot": schema.StringAttribute{
+                Description: "Spec of the snapshot to clone, e.g. the id of a ceph_rbd_snapshot. Changing it replaces the clone unless it has been flattened; renaming the parent snapshot or its image does not.",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplaceIf(
+                        r.requiresReplaceParentSnapshot,
+                        "Changing the parent snapshot replaces the clone unless it has been flattened or the new spec names its current parent, e.g. after a rename.",
+                        "Changing the parent snapshot replaces the clone unless it has been flattened or the new spec names its current parent, e.g. after a rename.",
+                    ),
+                },
+                Validators: []validator.String{
+                    rbdSnapshotSpecValidator{},
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool holding the clone",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "namespace": schema.StringAttribute{
+                Description: "RBD namespace of the clone within the pool",
+                Optional:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the clone. Changing it renames the clone in place.",
+                Required:    true,
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+            "size": schema.StringAttribute{
+                Description: "Size of the clone, either in bytes or with a unit such as \"10GiB\". Defaults to the size of the parent snapshot. Can be grown in place; shrinking requires allow_shrink.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "allow_shrink": schema.BoolAttribute{
+                Description: "Allow reducing size, which discards the data beyond the new size.",
+                Optional:    true,
+            },
+            "features": schema.SetAttribute{
+                Description: "Image features (layering, exclusive-lock, object-map, fast-diff, deep-flatten, journaling). Defaults to the cluster's rbd_default_features.",
+                ElementType: types.StringType,
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Set{
+                    setplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.Set{
+                    setvalidator.ValueStringsAre(stringvalidator.OneOf(rbdFeatures...)),
+                },
+            },
+            "data_pool": schema.StringAttribute{
+                Description: "Pool storing the clone's data while pool keeps the metadata. Cannot be changed after creation.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "flatten": schema.BoolAttribute{
+                Description: "Flatten the clone, copying the data it shares with the parent snapshot so that it no longer depends on it. Flattening cannot be undone; unsetting it leaves the clone flattened.",
+                Optional:    true,
+            },
+            "flattened": schema.BoolAttribute{
+                Description: "Whether the clone no longer depends on its parent snapshot",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Bool{
+                    boolplanmodifier.UseStateForUnknown(),
+                },
+            },
+        },
+        Blocks: map[string]schema.Block{
+            "timeouts": timeouts.Block(ctx, timeouts.Opts{
+                Create: true,
+                Update: true,
+                Delete: true,
+            }),
+        },
+    }
+}
+
+// requiresReplaceParentSnapshot replaces the clone when its parent snapshot
+// changes. Flattened clones no longer have a parent. Renaming the parent
+// snapshot or its image changes the spec but not the parent, so the clone is
+// kept when Ceph reports the new spec as its parent, or when the new spec
+// does not exist yet because the rename is part of the same apply; Update
+// checks the parent again before changing anything.
+func (r *rbdCloneResource) requiresReplaceParentSnapshot(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+    var id types.String
+    var flattened types.Bool
+    resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+    resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("flattened"), &flattened)...)
+    if resp.Diagnostics.HasError() || flattened.ValueBool() {
+        return
+    }
+
+    resp.RequiresReplace = true
+    if r.client == nil || req.PlanValue.IsUnknown() || id.ValueString() == "" {
+        return
+    }
+
+    image, err := r.client.GetRBDImage(ctx, id.ValueString())
+    if errors.Is(err, errRBDImageNotFound) {
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Clone",
+            fmt.Sprintf("Could not read RBD clone %s: %s", id.ValueString(), err.Error()),
+        )
+        return
+    }
+
+    planned := req.PlanValue.ValueString()
+    if image.Parent == nil || image.Parent.SnapshotSpec() == planned {
+        resp.RequiresReplace = false
+        return
+    }
+
+    // The spec was checked by the schema validator
+    imageSpec, snapshot, _ := parseRBDSnapshotSpec(planned)
+    _, err = r.client.GetRBDSnapshot(ctx, imageSpec, snapshot)
+    if errors.Is(err, errRBDSnapshotNotFound) {
+        resp.RequiresReplace = false
+        resp.Diagnostics.AddWarning(
+            "RBD Clone Parent Snapshot Not Found",
+            fmt.Sprintf("RBD snapshot %s does not exist yet, so clone %s is planned to keep its parent %s, assuming the snapshot or its image is renamed by this apply. If %s is a different snapshot, the apply fails without changing the clone and the next plan replaces it.", planned, id.ValueString(), image.Parent.SnapshotSpec(), planned),
+        )
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Snapshot",
+            fmt.Sprintf("Could not read RBD snapshot %s: %s", planned, err.Error()),
+        )
+    }
+}
+
+// ModifyPlan refuses to shrink clones unless allowed, plans the new id when
+// the clone is renamed and plans flattened when flattening
+func (r *rbdCloneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    // Nothing to plan when the clone is destroyed
+    if req.Plan.Raw.IsNull() {
+        return
+    }
+
+    var plan RBDCloneResourceModel
+
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if plan.Flatten.ValueBool() {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("flattened"), types.BoolValue(true))...)
+    }
+
+    if req.State.Raw.IsNull() {
+        return
+    }
+
+    var state RBDCloneResourceModel
+
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    checkRBDImageShrink(&resp.Diagnostics, state.ID.ValueString(), plan.Size, state.Size, plan.AllowShrink)
+
+    if !plan.Name.Equal(state.Name) {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), renamedRBDImageID(plan.Pool, plan.Namespace, plan.Name))...)
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdCloneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDCloneResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    createTimeout, diags := plan.Timeouts.Create(ctx, defaultRBDCloneCreateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, createTimeout)
+    defer cancel()
+
+    // The parent spec was checked by the schema validator
+    parentImage, parentSnapshot, _ := parseRBDSnapshotSpec(plan.ParentSnapshot.ValueString())
+
+    cloneReq := RBDCloneRequest{
+        ChildPoolName:  plan.Pool.ValueString(),
+        ChildImageName: plan.Name.ValueString(),
+        ChildNamespace: plan.Namespace.ValueString(),
+    }
+    if isKnown(plan.Features) {
+        resp.Diagnostics.Append(plan.Features.ElementsAs(ctx, &cloneReq.Features, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+    }
+    if isKnown(plan.DataPool) {
+        cloneReq.DataPool = plan.DataPool.ValueString()
+    }
+
+    spec := rbdImageSpec(cloneReq.ChildPoolName, cloneReq.ChildNamespace, cloneReq.ChildImageName)
+    err := r.client.CloneRBDSnapshot(ctx, parentImage, parentSnapshot, cloneReq)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph RBD Clone",
+            fmt.Sprintf("Could not clone RBD snapshot %s to %s: %s", plan.ParentSnapshot.ValueString(), spec, err.Error()),
+        )
+        return
+    }
+
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph RBD Clone",
+            fmt.Sprintf("Could not read created RBD clone %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Clones start out with the size of the parent snapshot
+    if isKnown(plan.Size) {
+        size, _ := parseByteSize(plan.Size.ValueString())
+        if size != image.Size {
+            err = r.client.UpdateRBDImage(ctx, spec, map[string]interface{}{"size": size})
+            if err != nil {
+                resp.Diagnostics.AddError(
+                    "Error Resizing Ceph RBD Clone",
+                    fmt.Sprintf("Could not resize RBD clone %s: %s", spec, err.Error()),
+                )
+                return
+            }
+        }
+    }
+
+    if plan.Flatten.ValueBool() {
+        err = r.client.FlattenRBDImage(ctx, spec)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Flattening Ceph RBD Clone",
+                fmt.Sprintf("Could not flatten RBD clone %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    // Read back the values chosen by Ceph, e.g. the default features
+    image, err = r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph RBD Clone",
+            fmt.Sprintf("Could not read created RBD clone %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDClone(image)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDCloneResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Get clone information from Ceph
+    spec := state.ID.ValueString()
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        // If the clone is not found, remove it from state
+        if errors.Is(err, errRBDImageNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Clone",
+            fmt.Sprintf("Could not read RBD clone %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Update the state with the latest data
+    state.setRBDClone(image)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDCloneResourceModel
+    var state RBDCloneResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    updateTimeout, diags := plan.Timeouts.Update(ctx, defaultRBDCloneUpdateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+    defer cancel()
+
+    // A parent snapshot change that was not planned as a replacement must be
+    // a rename of the clone's parent, which has happened by now
+    spec := state.ID.ValueString()
+    if !plan.ParentSnapshot.Equal(state.ParentSnapshot) && !state.Flattened.ValueBool() {
+        image, err := r.client.GetRBDImage(ctx, spec)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Clone",
+                fmt.Sprintf("Could not read RBD clone %s: %s", spec, err.Error()),
+            )
+            return
+        }
+        if image.Parent != nil && image.Parent.SnapshotSpec() != plan.ParentSnapshot.ValueString() {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("parent_snapshot"),
+                "RBD Clone Parent Snapshot Changed",
+                fmt.Sprintf("RBD clone %s was cloned from %s, not %s. Changing the parent snapshot replaces the clone; run terraform plan again to plan the replacement.", spec, image.Parent.SnapshotSpec(), plan.ParentSnapshot.ValueString()),
+            )
+            return
+        }
+    }
+
+    // Rename the clone first, the remaining changes address it by spec
+    if plan.Name.ValueString() != state.Name.ValueString() {
+        err := r.client.RenameRBDImage(ctx, spec, plan.Name.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Renaming Ceph RBD Clone",
+                fmt.Sprintf("Could not rename RBD clone %s to %s: %s", spec, plan.Name.ValueString(), err.Error()),
+            )
+            return
+        }
+        spec = rbdImageSpec(plan.Pool.ValueString(), plan.Namespace.ValueString(), plan.Name.ValueString())
+    }
+
+    // Collect the other changes, which the Dashboard applies in one request
+    changes := map[string]interface{}{}
+
+    if isKnown(plan.Size) {
+        planSize, _ := parseByteSize(plan.Size.ValueString())
+        stateSize, _ := parseByteSize(state.Size.ValueString())
+        if planSize != stateSize {
+            changes["size"] = planSize
+        }
+    }
+
+    if isKnown(plan.Features) && !plan.Features.Equal(state.Features) {
+        var features []string
+        resp.Diagnostics.Append(plan.Features.ElementsAs(ctx, &features, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+        changes["features"] = features
+    }
+
+    if len(changes) > 0 {
+        err := r.client.UpdateRBDImage(ctx, spec, changes)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Clone",
+                fmt.Sprintf("Could not update RBD clone %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    if plan.Flatten.ValueBool() && !state.Flattened.ValueBool() {
+        err := r.client.FlattenRBDImage(ctx, spec)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Flattening Ceph RBD Clone",
+                fmt.Sprintf("Could not flatten RBD clone %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Updated Ceph RBD Clone",
+            fmt.Sprintf("Could not read updated RBD clone %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDClone(image)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDCloneResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultRBDCloneDeleteTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+    defer cancel()
+
+    // Delete the clone, which releases the parent snapshot
+    spec := state.ID.ValueString()
+    err := r.client.DeleteRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Clone",
+            fmt.Sprintf("Could not delete RBD clone %s: %s", spec, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *rbdCloneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Clones are imported by image spec, Read fills in the parent snapshot
+    if _, _, _, err := parseRBDImageSpec(req.ID); err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import RBD clone: %s", err.Error()),
+        )
+        return
+    }
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD clone resource
+func TestAccRBDCloneResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// newTestCloneServer serves the clone rbd/vm-1 of rbd/golden@v1, where
+// rbd/golden also has a snapshot v2, failing the test on any request that
+// would change them
+func newTestCloneServer(t *testing.T) *httptest.Server {
+    return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != "GET" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusMethodNotAllowed)
+            return
+        }
+        
+        switch r.URL.Path {
+        case "/api/block/image/rbd/vm-1":
+            json.NewEncoder(w).Encode(RBDImage{
+                Name:     "vm-1",
+                PoolName: "rbd",
+                Parent:   &RBDImageParent{PoolName: "rbd", ImageName: "golden", SnapName: "v1"},
+            })
+        case "/api/block/image/rbd/golden":
+            json.NewEncoder(w).Encode(RBDImage{
+                Name:      "golden",
+                PoolName:  "rbd",
+                Snapshots: []RBDSnapshot{{ID: 4, Name: "v1"}, {ID: 7, Name: "v2"}},
+            })
+        default:
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+}
+
+// TestRequiresReplaceParentSnapshot tests that changing the parent snapshot
+// only replaces clones that still depend on a different snapshot
+func TestRequiresReplaceParentSnapshot(t *testing.T) {
+    server := newTestCloneServer(t)
+    defer server.Close()
+    
+    tests := map[string]struct {
+        client    *CephClient
+        flattened tftypes.Value
+        planned   string
+        want      bool
+        warning   bool
+    }{
+        "not flattened": {flattened: tftypes.NewValue(tftypes.Bool, false), planned: "rbd/golden@v2", want: true},
+        "flattened":     {flattened: tftypes.NewValue(tftypes.Bool, true), planned: "rbd/golden@v2", want: false},
+        "imported":      {flattened: tftypes.NewValue(tftypes.Bool, nil), planned: "rbd/golden@v2", want: true},
+        "different snapshot": {
+            client:    newTestRetryClient(server),
+            flattened: tftypes.NewValue(tftypes.Bool, false),
+            planned:   "rbd/golden@v2",
+            want:      true,
+        },
+        "current parent": {
+            client:    newTestRetryClient(server),
+            flattened: tftypes.NewValue(tftypes.Bool, false),
+            planned:   "rbd/golden@v1",
+            want:      false,
+        },
+        "snapshot renamed": {
+            client:    newTestRetryClient(server),
+            flattened: tftypes.NewValue(tftypes.Bool, false),
+            planned:   "rbd/golden@v3",
+            want:      false,
+            warning:   true,
+        },
+        "image renamed": {
+            client:    newTestRetryClient(server),
+            flattened: tftypes.NewValue(tftypes.Bool, false),
+            planned:   "rbd/golden-2@v1",
+            want:      false,
+            warning:   true,
+        },
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            r := &rbdCloneResource{client: tt.client}
+            s, state := testResourceValue(t, r, map[string]tftypes.Value{
+                "id":              tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+                "parent_snapshot": tftypes.NewValue(tftypes.String, "rbd/golden@old"),
+                "flattened":       tt.flattened,
+            })
+            
+            req := planmodifier.StringRequest{
+                Path:       path.Root("parent_snapshot"),
+                State:      tfsdk.State{Schema: s, Raw: state},
+                StateValue: types.StringValue("rbd/golden@old"),
+                PlanValue:  types.StringValue(tt.planned),
+            }
+            var resp stringplanmodifier.RequiresReplaceIfFuncResponse
+            r.requiresReplaceParentSnapshot(context.Background(), req, &resp)
+            
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            if resp.RequiresReplace != tt.want {
+                t.Errorf("Expected RequiresReplace %t, got %t", tt.want, resp.RequiresReplace)
+            }
+            if got := resp.Diagnostics.WarningsCount() > 0; got != tt.warning {
+                t.Errorf("Expected warning %t, got %v", tt.warning, resp.Diagnostics)
+            }
+        })
+    }
+}
+
+// TestRBDCloneResourceUpdateParentChanged tests that an in-place update
+// refuses a parent snapshot that is not the clone's parent
+func TestRBDCloneResourceUpdateParentChanged(t *testing.T) {
+    server := newTestCloneServer(t)
+    defer server.Close()
+    
+    r := &rbdCloneResource{client: newTestRetryClient(server)}
+    values := map[string]tftypes.Value{
+        "id":              tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+        "parent_snapshot": tftypes.NewValue(tftypes.String, "rbd/golden@v1"),
+        "pool":            tftypes.NewValue(tftypes.String, "rbd"),
+        "name":            tftypes.NewValue(tftypes.String, "vm-1"),
+        "flattened":       tftypes.NewValue(tftypes.Bool, false),
+    }
+    s, state := testResourceValue(t, r, values)
+    values["parent_snapshot"] = tftypes.NewValue(tftypes.String, "rbd/golden@v2")
+    _, plan := testResourceValue(t, r, values)
+    
+    req := resource.UpdateRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+        State: tfsdk.State{Schema: s, Raw: state},
+    }
+    resp := resource.UpdateResponse{State: req.State}
+    r.Update(context.Background(), req, &resp)
+    
+    if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "RBD Clone Parent Snapshot Changed" {
+        t.Errorf("Expected the parent snapshot error, got %v", resp.Diagnostics)
+    }
+}
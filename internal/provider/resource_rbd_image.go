@@ -0,0 +1,560 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+    "github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts for RBD image operations that wait on Dashboard background tasks
+const (
+    defaultRBDImageCreateTimeout = 10 * time.Minute
+    defaultRBDImageUpdateTimeout = 20 * time.Minute
+    defaultRBDImageDeleteTimeout = 20 * time.Minute
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdImageResource{}
+    _ resource.ResourceWithConfigure   = &rbdImageResource{}
+    _ resource.ResourceWithImportState = &rbdImageResource{}
+    _ resource.ResourceWithModifyPlan  = &rbdImageResource{}
+)
+
+// NewRBDImageResource is a helper function to simplify the provider implementation
+func NewRBDImageResource() resource.Resource {
+    return &rbdImageResource{}
+}
+
+// rbdImageResource is the resource implementation
+type rbdImageResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdImageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_image"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a Ceph RBD image.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Image spec, pool/namespace/image or pool/image outside of a namespace",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool holding the image",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "namespace": schema.StringAttribute{
+                Description: "RBD namespace of the image within the pool",
+                Optional:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the image. Changing it renames the image in place.",
+                Required:    true,
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+            "size": schema.StringAttribute{
+                Description: "Size of the image, either in bytes or with a unit such as \"10GiB\". Can be grown in place; shrinking requires allow_shrink.",
+                Required:    true,
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "allow_shrink": schema.BoolAttribute{
+                Description: "Allow reducing size, which discards the data beyond the new size.",
+                Optional:    true,
+            },
+            "features": schema.SetAttribute{
+                Description: "Image features (layering, exclusive-lock, object-map, fast-diff, deep-flatten, journaling). Defaults to the cluster's rbd_default_features. Features that librbd sets by itself, such as striping or data-pool, are not listed.",
+                ElementType: types.StringType,
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Set{
+                    setplanmodifier.UseStateForUnknown(),
+                },
+                Validators: []validator.Set{
+                    setvalidator.ValueStringsAre(stringvalidator.OneOf(rbdFeatures...)),
+                },
+            },
+            "object_size": schema.StringAttribute{
+                Description: "Size of the objects the image is striped over, a power of two such as \"4MiB\". Cannot be changed after creation.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "stripe_unit": schema.StringAttribute{
+                Description: "Size of the stripes written to each object, dividing object_size. Defaults to object_size. Cannot be changed after creation.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    byteSizeValidator{},
+                },
+            },
+            "stripe_count": schema.Int64Attribute{
+                Description: "Number of objects striped over before returning to the first. Defaults to 1. Cannot be changed after creation.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                    int64planmodifier.RequiresReplace(),
+                },
+                Validators: []validator.Int64{
+                    int64validator.AtLeast(1),
+                },
+            },
+            "data_pool": schema.StringAttribute{
+                Description: "Pool storing the image data, e.g. an erasure coded pool with allow_ec_overwrites, while pool keeps the metadata. Defaults to the cluster's rbd_default_data_pool. Cannot be changed after creation.",
+                Optional:    true,
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+        },
+        Blocks: map[string]schema.Block{
+            "qos": rbdQoSBlock("image"),
+            "timeouts": timeouts.Block(ctx, timeouts.Opts{
+                Create: true,
+                Update: true,
+                Delete: true,
+            }),
+        },
+    }
+}
+
+// rbdQoSBlock returns the schema of the RBD QoS overrides of an image or pool
+func rbdQoSBlock(level string) schema.SingleNestedBlock {
+    iops := func(description string) schema.Int64Attribute {
+        return schema.Int64Attribute{
+            Description: description,
+            Optional:    true,
+            Validators: []validator.Int64{
+                int64validator.AtLeast(0),
+            },
+        }
+    }
+    bytes := func(description string) schema.StringAttribute {
+        return schema.StringAttribute{
+            Description: description + ", either in bytes or with a unit such as \"100MiB\"",
+            Optional:    true,
+            Validators: []validator.String{
+                byteSizeValidator{},
+            },
+        }
+    }
+
+    return schema.SingleNestedBlock{
+        Description: fmt.Sprintf("RBD QoS settings overridden for the %s. Settings left out, or the whole block, are inherited again. A value of 0 means unlimited.", level),
+        Attributes: map[string]schema.Attribute{
+            "iops_limit":       iops("Maximum I/O operations per second (rbd_qos_iops_limit)"),
+            "read_iops_limit":  iops("Maximum read operations per second (rbd_qos_read_iops_limit)"),
+            "write_iops_limit": iops("Maximum write operations per second (rbd_qos_write_iops_limit)"),
+            "bps_limit":        bytes("Maximum bytes per second (rbd_qos_bps_limit)"),
+            "read_bps_limit":   bytes("Maximum bytes read per second (rbd_qos_read_bps_limit)"),
+            "write_bps_limit":  bytes("Maximum bytes written per second (rbd_qos_write_bps_limit)"),
+            "iops_burst":       iops("I/O operations per second allowed in bursts (rbd_qos_iops_burst)"),
+            "read_iops_burst":  iops("Read operations per second allowed in bursts (rbd_qos_read_iops_burst)"),
+            "write_iops_burst": iops("Write operations per second allowed in bursts (rbd_qos_write_iops_burst)"),
+            "bps_burst":        bytes("Bytes per second allowed in bursts (rbd_qos_bps_burst)"),
+            "read_bps_burst":   bytes("Bytes read per second allowed in bursts (rbd_qos_read_bps_burst)"),
+            "write_bps_burst":  bytes("Bytes written per second allowed in bursts (rbd_qos_write_bps_burst)"),
+        },
+    }
+}
+
+// ModifyPlan refuses to shrink images unless allowed and plans the new id
+// when the image is renamed
+func (r *rbdImageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    // Nothing to check when the image is created or destroyed
+    if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+        return
+    }
+
+    var plan, state RBDImageResourceModel
+
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    checkRBDImageShrink(&resp.Diagnostics, state.ID.ValueString(), plan.Size, state.Size, plan.AllowShrink)
+
+    if !plan.Name.Equal(state.Name) {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), renamedRBDImageID(plan.Pool, plan.Namespace, plan.Name))...)
+    }
+}
+
+// renamedRBDImageID returns the planned id of a renamed image. Planning an
+// unknown id instead would make snapshots, clones and mirroring referencing
+// the id plan a replacement.
+func renamedRBDImageID(pool, namespace, name types.String) types.String {
+    if !isKnown(pool) || namespace.IsUnknown() || !isKnown(name) {
+        return types.StringUnknown()
+    }
+    return types.StringValue(rbdImageSpec(pool.ValueString(), namespace.ValueString(), name.ValueString()))
+}
+
+// requiresReplaceRenamedImage decides whether changing the image spec of a
+// resource attached to an RBD image, such as a snapshot, requires replacing
+// it. Renaming the image changes its spec but not the image: a new spec that
+// does not exist yet is assumed to be renamed by the same apply, which Update
+// checks, and an existing one is the renamed image when the old spec is gone
+// and renamed reports true for it.
+func requiresReplaceRenamedImage(ctx context.Context, client *CephClient, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse, renamed func(*RBDImage) bool) {
+    resp.RequiresReplace = true
+    if client == nil || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+        return
+    }
+
+    oldSpec, newSpec := req.StateValue.ValueString(), req.PlanValue.ValueString()
+    image, err := client.GetRBDImage(ctx, newSpec)
+    if errors.Is(err, errRBDImageNotFound) {
+        resp.RequiresReplace = false
+        resp.Diagnostics.AddWarning(
+            "RBD Image Not Found",
+            fmt.Sprintf("RBD image %s does not exist yet, so the resource is planned to follow image %s, assuming it is renamed by this apply. If %s is a different image, the apply fails without changing the resource and the next plan replaces it.", newSpec, oldSpec, newSpec),
+        )
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Image",
+            fmt.Sprintf("Could not read RBD image %s: %s", newSpec, err.Error()),
+        )
+        return
+    }
+
+    // Both images exist, so the resource moves to a different image
+    _, err = client.GetRBDImage(ctx, oldSpec)
+    if err == nil {
+        return
+    }
+    if !errors.Is(err, errRBDImageNotFound) {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Image",
+            fmt.Sprintf("Could not read RBD image %s: %s", oldSpec, err.Error()),
+        )
+        return
+    }
+    resp.RequiresReplace = !renamed(image)
+}
+
+// checkRBDImageShrink adds an error diagnostic when the planned size is below
+// the current one and shrinking is not allowed
+func checkRBDImageShrink(diags *diag.Diagnostics, spec string, planned, current types.String, allowShrink types.Bool) {
+    if !isKnown(planned) || !isKnown(current) {
+        return
+    }
+
+    // Both sizes were checked by the schema validator
+    planSize, _ := parseByteSize(planned.ValueString())
+    stateSize, _ := parseByteSize(current.ValueString())
+    if planSize < stateSize && !allowShrink.ValueBool() {
+        diags.AddAttributeError(
+            path.Root("size"),
+            "RBD Image Shrink Not Allowed",
+            fmt.Sprintf("Shrinking image %s from %d to %d bytes discards the data beyond the new size. Set allow_shrink = true to allow it.", spec, stateSize, planSize),
+        )
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdImageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDImageResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    createTimeout, diags := plan.Timeouts.Create(ctx, defaultRBDImageCreateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, createTimeout)
+    defer cancel()
+
+    // Sizes were already checked by the schema validators
+    imageReq := RBDImageCreateRequest{
+        Name:      plan.Name.ValueString(),
+        PoolName:  plan.Pool.ValueString(),
+        Namespace: plan.Namespace.ValueString(),
+    }
+    imageReq.Size, _ = parseByteSize(plan.Size.ValueString())
+
+    if isKnown(plan.Features) {
+        resp.Diagnostics.Append(plan.Features.ElementsAs(ctx, &imageReq.Features, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+    }
+    if isKnown(plan.ObjectSize) {
+        imageReq.ObjSize, _ = parseByteSize(plan.ObjectSize.ValueString())
+    }
+    if isKnown(plan.StripeUnit) {
+        imageReq.StripeUnit, _ = parseByteSize(plan.StripeUnit.ValueString())
+    }
+    if isKnown(plan.StripeCount) {
+        imageReq.StripeCount = plan.StripeCount.ValueInt64()
+    }
+    if isKnown(plan.DataPool) {
+        imageReq.DataPool = plan.DataPool.ValueString()
+    }
+    if configuration := plan.QoS.configuration(); len(configuration) > 0 {
+        imageReq.Configuration = configuration
+    }
+
+    spec := rbdImageSpec(imageReq.PoolName, imageReq.Namespace, imageReq.Name)
+    err := r.client.CreateRBDImage(ctx, imageReq)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph RBD Image",
+            fmt.Sprintf("Could not create RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Read back the values chosen by Ceph, e.g. the default features
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph RBD Image",
+            fmt.Sprintf("Could not read created RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDImage(image)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDImageResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Get image information from Ceph
+    spec := state.ID.ValueString()
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        // If the image is not found, remove it from state
+        if errors.Is(err, errRBDImageNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Image",
+            fmt.Sprintf("Could not read RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Update the state with the latest data
+    state.setRBDImage(image)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDImageResourceModel
+    var state RBDImageResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    updateTimeout, diags := plan.Timeouts.Update(ctx, defaultRBDImageUpdateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+    defer cancel()
+
+    // Rename the image first, the remaining changes address it by spec
+    spec := state.ID.ValueString()
+    if plan.Name.ValueString() != state.Name.ValueString() {
+        err := r.client.RenameRBDImage(ctx, spec, plan.Name.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Renaming Ceph RBD Image",
+                fmt.Sprintf("Could not rename RBD image %s to %s: %s", spec, plan.Name.ValueString(), err.Error()),
+            )
+            return
+        }
+        spec = rbdImageSpec(plan.Pool.ValueString(), plan.Namespace.ValueString(), plan.Name.ValueString())
+    }
+
+    // Collect the other changes, which the Dashboard applies in one request
+    changes := map[string]interface{}{}
+
+    planSize, _ := parseByteSize(plan.Size.ValueString())
+    stateSize, _ := parseByteSize(state.Size.ValueString())
+    if planSize != stateSize {
+        changes["size"] = planSize
+    }
+
+    if isKnown(plan.Features) && !plan.Features.Equal(state.Features) {
+        var features []string
+        resp.Diagnostics.Append(plan.Features.ElementsAs(ctx, &features, false)...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+        changes["features"] = features
+    }
+
+    if configuration := rbdQoSChanges(plan.QoS, state.QoS); len(configuration) > 0 {
+        changes["configuration"] = configuration
+    }
+
+    if len(changes) > 0 {
+        err := r.client.UpdateRBDImage(ctx, spec, changes)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Image",
+                fmt.Sprintf("Could not update RBD image %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Updated Ceph RBD Image",
+            fmt.Sprintf("Could not read updated RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDImage(image)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDImageResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultRBDImageDeleteTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+    defer cancel()
+
+    // Delete the image
+    spec := state.ID.ValueString()
+    err := r.client.DeleteRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Image",
+            fmt.Sprintf("Could not delete RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *rbdImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Images are imported by spec, Read fills in the rest
+    if _, _, _, err := parseRBDImageSpec(req.ID); err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import RBD image: %s", err.Error()),
+        )
+        return
+    }
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource              = &rbdMirrorBootstrapTokenResource{}
+    _ resource.ResourceWithConfigure = &rbdMirrorBootstrapTokenResource{}
+)
+
+// NewRBDMirrorBootstrapTokenResource is a helper function to simplify the provider implementation
+func NewRBDMirrorBootstrapTokenResource() resource.Resource {
+    return &rbdMirrorBootstrapTokenResource{}
+}
+
+// rbdMirrorBootstrapTokenResource is the resource implementation
+type rbdMirrorBootstrapTokenResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdMirrorBootstrapTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_mirror_bootstrap_token"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdMirrorBootstrapTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Creates a bootstrap token for adding this cluster as RBD mirror peer of a pool on another cluster with ceph_rbd_mirror_peer. Mirroring must be enabled on the pool. Destroying the resource only removes it from the state.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Name of the pool",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool to mirror",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "token": schema.StringAttribute{
+                Description: "Bootstrap token, which holds credentials for this cluster",
+                Computed:    true,
+                Sensitive:   true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdMirrorBootstrapTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdMirrorBootstrapTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDMirrorBootstrapTokenResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := plan.Pool.ValueString()
+    token, err := r.client.CreateRBDMirrorBootstrapToken(ctx, pool)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph RBD Mirror Bootstrap Token",
+            fmt.Sprintf("Could not create mirror bootstrap token for pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    plan.ID = types.StringValue(pool)
+    plan.Token = types.StringValue(token)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the token from the state once its pool is gone. Tokens cannot
+// be read back, so the state is kept otherwise.
+func (r *rbdMirrorBootstrapTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDMirrorBootstrapTokenResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    _, err := r.client.GetRBDMirrorPoolMode(ctx, pool)
+    if err != nil {
+        if errors.Is(err, errPoolNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Pool Mirroring",
+            fmt.Sprintf("Could not read mirror mode of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+}
+
+// Update is never called as every attribute requires replacement
+func (r *rbdMirrorBootstrapTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "RBD Mirror Bootstrap Tokens Cannot Be Updated",
+        "RBD mirror bootstrap tokens are immutable and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete removes the token from the state. The token stays valid, as the
+// cluster keeps the mirror peer user it grants access to.
+func (r *rbdMirrorBootstrapTokenResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,322 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "regexp"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rbdMirrorScheduleIntervalRegex matches mirror snapshot schedule intervals
+// such as "30m", "1h" or "1d"
+var rbdMirrorScheduleIntervalRegex = regexp.MustCompile(`^[1-9][0-9]*[mhd]$`)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdMirrorImageResource{}
+    _ resource.ResourceWithConfigure   = &rbdMirrorImageResource{}
+    _ resource.ResourceWithImportState = &rbdMirrorImageResource{}
+    _ resource.ResourceWithModifyPlan  = &rbdMirrorImageResource{}
+)
+
+// NewRBDMirrorImageResource is a helper function to simplify the provider implementation
+func NewRBDMirrorImageResource() resource.Resource {
+    return &rbdMirrorImageResource{}
+}
+
+// rbdMirrorImageResource is the resource implementation
+type rbdMirrorImageResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdMirrorImageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_mirror_image"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdMirrorImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Enables snapshot-based mirroring of an RBD image in a pool with mirror mode image. Destroying the resource disables mirroring of the image.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Image spec, pool/namespace/image or pool/image outside of a namespace",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "image": schema.StringAttribute{
+                Description: "Spec of the image to mirror, e.g. the id of a ceph_rbd_image. Changing it replaces the resource unless the image was renamed.",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplaceIf(
+                        r.requiresReplaceImage,
+                        "Changing the image replaces the resource unless the image was renamed.",
+                        "Changing the image replaces the resource unless the image was renamed.",
+                    ),
+                },
+                Validators: []validator.String{
+                    rbdImageSpecValidator{},
+                },
+            },
+            "schedule_interval": schema.StringAttribute{
+                Description: "Interval mirror snapshots are taken at, in minutes, hours or days such as \"30m\", \"1h\" or \"1d\". Without it mirror snapshots must be taken by other means. The schedule is not read back from the cluster.",
+                Optional:    true,
+                Validators: []validator.String{
+                    stringvalidator.RegexMatches(rbdMirrorScheduleIntervalRegex, "must be a number followed by m, h or d"),
+                },
+            },
+            "primary": schema.BoolAttribute{
+                Description: "Whether the image is primary on this cluster, i.e. writable and mirrored to the peers",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Bool{
+                    boolplanmodifier.UseStateForUnknown(),
+                },
+            },
+        },
+    }
+}
+
+// requiresReplaceImage replaces the resource when it moves to a different
+// image, but not when its image is renamed and is still mirrored
+func (r *rbdMirrorImageResource) requiresReplaceImage(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+    requiresReplaceRenamedImage(ctx, r.client, req, resp, func(image *RBDImage) bool {
+        return image.MirrorMode == rbdImageMirrorModeSnapshot
+    })
+}
+
+// ModifyPlan plans the new id when the image is renamed
+func (r *rbdMirrorImageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    // Nothing to plan when mirroring is enabled or disabled
+    if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+        return
+    }
+
+    var plan, state RBDMirrorImageResourceModel
+
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if !plan.Image.Equal(state.Image) {
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), plan.Image)...)
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdMirrorImageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdMirrorImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDMirrorImageResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    spec := plan.Image.ValueString()
+    err := r.client.EnableRBDImageMirroring(ctx, spec, plan.ScheduleInterval.ValueString())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Enabling Ceph RBD Image Mirroring",
+            fmt.Sprintf("Could not enable mirroring of RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Image Mirroring",
+            fmt.Sprintf("Could not read RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.ID = types.StringValue(spec)
+    plan.Primary = types.BoolValue(image.Primary)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdMirrorImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDMirrorImageResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    spec := state.ID.ValueString()
+    image, err := r.client.GetRBDImage(ctx, spec)
+    if err != nil {
+        // If the image is not found, remove it from state
+        if errors.Is(err, errRBDImageNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Image Mirroring",
+            fmt.Sprintf("Could not read RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Mirroring was disabled or switched to journaling outside of Terraform
+    if image.MirrorMode != rbdImageMirrorModeSnapshot {
+        resp.State.RemoveResource(ctx)
+        return
+    }
+
+    state.Image = types.StringValue(spec)
+    state.Primary = types.BoolValue(image.Primary)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdMirrorImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDMirrorImageResourceModel
+    var state RBDMirrorImageResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // An image change that was not planned as a replacement must be a rename
+    // of the mirrored image, which has happened by now
+    spec := state.ID.ValueString()
+    if plan.Image.ValueString() != spec {
+        image, err := r.client.GetRBDImage(ctx, plan.Image.ValueString())
+        if err != nil && !errors.Is(err, errRBDImageNotFound) {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Image Mirroring",
+                fmt.Sprintf("Could not read RBD image %s: %s", plan.Image.ValueString(), err.Error()),
+            )
+            return
+        }
+        _, oldErr := r.client.GetRBDImage(ctx, spec)
+        if oldErr != nil && !errors.Is(oldErr, errRBDImageNotFound) {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Image Mirroring",
+                fmt.Sprintf("Could not read RBD image %s: %s", spec, oldErr.Error()),
+            )
+            return
+        }
+        if err != nil || oldErr == nil || image.MirrorMode != rbdImageMirrorModeSnapshot {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("image"),
+                "RBD Mirrored Image Changed",
+                fmt.Sprintf("RBD image %s is not the mirrored image %s renamed. Changing the image replaces the resource; run terraform plan again to plan the replacement.", plan.Image.ValueString(), spec),
+            )
+            return
+        }
+        spec = plan.Image.ValueString()
+    }
+
+    if !plan.ScheduleInterval.Equal(state.ScheduleInterval) {
+        err := r.client.SetRBDImageMirrorSchedule(ctx, spec, plan.ScheduleInterval.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Image Mirroring",
+                fmt.Sprintf("Could not change the mirror snapshot schedule of RBD image %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    plan.ID = types.StringValue(spec)
+    plan.Primary = state.Primary
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdMirrorImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDMirrorImageResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Nothing to disable once the image is gone
+    spec := state.ID.ValueString()
+    if _, err := r.client.GetRBDImage(ctx, spec); errors.Is(err, errRBDImageNotFound) {
+        return
+    }
+
+    // Schedules outlive disabling mirroring, so remove them first
+    if !state.ScheduleInterval.IsNull() {
+        err := r.client.SetRBDImageMirrorSchedule(ctx, spec, "")
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Disabling Ceph RBD Image Mirroring",
+                fmt.Sprintf("Could not remove the mirror snapshot schedule of RBD image %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    err := r.client.DisableRBDImageMirroring(ctx, spec)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Disabling Ceph RBD Image Mirroring",
+            fmt.Sprintf("Could not disable mirroring of RBD image %s: %s", spec, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *rbdMirrorImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Mirrored images are imported by spec, Read fills in the rest
+    if _, _, _, err := parseRBDImageSpec(req.ID); err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import RBD image mirroring: %s", err.Error()),
+        )
+        return
+    }
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD mirror image resource
+func TestAccRBDMirrorImageResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestRBDMirrorImageResourceDelete tests that the mirror snapshot schedule
+// is removed before mirroring is disabled, and that nothing is sent for an
+// image that is already gone
+func TestRBDMirrorImageResourceDelete(t *testing.T) {
+    tests := map[string]struct {
+        exists  bool
+        updates []string
+    }{
+        "mirrored": {exists: true, updates: []string{`{"remove_scheduling":true}`, `{"enable_mirror":false}`}},
+        "gone":     {exists: false},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            var updates []string
+            server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                if r.URL.Path != "/api/block/image/rbd/vm-1" || !tt.exists {
+                    w.WriteHeader(http.StatusNotFound)
+                    return
+                }
+                
+                switch r.Method {
+                case "GET":
+                    json.NewEncoder(w).Encode(RBDImage{Name: "vm-1", PoolName: "rbd"})
+                case "PUT":
+                    var body map[string]interface{}
+                    json.NewDecoder(r.Body).Decode(&body)
+                    update, _ := json.Marshal(body)
+                    updates = append(updates, string(update))
+                default:
+                    t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+                }
+            }))
+            defer server.Close()
+            
+            r := &rbdMirrorImageResource{client: newTestRetryClient(server)}
+            s, raw := testResourceValue(t, r, map[string]tftypes.Value{
+                "id":                tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+                "image":             tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+                "schedule_interval": tftypes.NewValue(tftypes.String, "1h"),
+            })
+            state := tfsdk.State{Schema: s, Raw: raw}
+            
+            resp := resource.DeleteResponse{State: state}
+            r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+            
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            if !reflect.DeepEqual(updates, tt.updates) {
+                t.Errorf("Expected updates %v, got %v", tt.updates, updates)
+            }
+        })
+    }
+}
+
+// TestRBDMirrorImageResourceUpdateImageRenamed tests that an in-place image
+// change follows a renamed image and is refused for any other image
+func TestRBDMirrorImageResourceUpdateImageRenamed(t *testing.T) {
+    mirrored := RBDImage{Name: "vm-2", PoolName: "rbd", MirrorMode: rbdImageMirrorModeSnapshot}
+    
+    tests := map[string]struct {
+        images    map[string]RBDImage
+        wantError bool
+    }{
+        "renamed":         {images: map[string]RBDImage{"rbd/vm-2": mirrored}},
+        "different image": {images: map[string]RBDImage{"rbd/vm-1": {Name: "vm-1", PoolName: "rbd"}, "rbd/vm-2": mirrored}, wantError: true},
+        "not mirrored":    {images: map[string]RBDImage{"rbd/vm-2": {Name: "vm-2", PoolName: "rbd"}}, wantError: true},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            server := newTestRBDImagesServer(t, tt.images)
+            defer server.Close()
+            
+            r := &rbdMirrorImageResource{client: newTestRetryClient(server)}
+            values := map[string]tftypes.Value{
+                "id":    tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+                "image": tftypes.NewValue(tftypes.String, "rbd/vm-1"),
+            }
+            s, state := testResourceValue(t, r, values)
+            values["id"] = tftypes.NewValue(tftypes.String, "rbd/vm-2")
+            values["image"] = tftypes.NewValue(tftypes.String, "rbd/vm-2")
+            _, plan := testResourceValue(t, r, values)
+            
+            req := resource.UpdateRequest{
+                Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+                State: tfsdk.State{Schema: s, Raw: state},
+            }
+            resp := resource.UpdateResponse{State: req.State}
+            r.Update(context.Background(), req, &resp)
+            
+            if tt.wantError {
+                if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "RBD Mirrored Image Changed" {
+                    t.Errorf("Expected the image changed error, got %v", resp.Diagnostics)
+                }
+                return
+            }
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            
+            var id types.String
+            resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+            if id.ValueString() != "rbd/vm-2" {
+                t.Errorf("Expected id rbd/vm-2, got %s", id)
+            }
+        })
+    }
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                   = &rbdMirrorPeerResource{}
+    _ resource.ResourceWithConfigure      = &rbdMirrorPeerResource{}
+    _ resource.ResourceWithValidateConfig = &rbdMirrorPeerResource{}
+)
+
+// NewRBDMirrorPeerResource is a helper function to simplify the provider implementation
+func NewRBDMirrorPeerResource() resource.Resource {
+    return &rbdMirrorPeerResource{}
+}
+
+// rbdMirrorPeerResource is the resource implementation
+type rbdMirrorPeerResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdMirrorPeerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_mirror_peer"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdMirrorPeerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Adds the cluster a bootstrap token was created on, e.g. with ceph_rbd_mirror_bootstrap_token through another provider alias, as RBD mirror peer of a pool. Mirroring must be enabled on the pool.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Peer identifier, pool/uuid",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the mirrored pool",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "token": schema.StringAttribute{
+                Description: "Bootstrap token created on the peer cluster",
+                Required:    true,
+                Sensitive:   true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "direction": schema.StringAttribute{
+                Description: "Mirroring direction: rx-tx (default) to mirror both ways, which also adds this cluster as peer on the other one, or rx to only receive images from the peer",
+                Optional:    true,
+                Computed:    true,
+                Default:     stringdefault.StaticString(rbdMirrorDirectionRxTx),
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.OneOf(rbdMirrorDirectionRxTx, rbdMirrorDirectionRx),
+                },
+            },
+            "uuid": schema.StringAttribute{
+                Description: "UUID of the peer",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "cluster_name": schema.StringAttribute{
+                Description: "Site name of the peer cluster",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "client_id": schema.StringAttribute{
+                Description: "Ceph user the peer cluster is accessed as",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "mon_host": schema.StringAttribute{
+                Description: "Monitor addresses of the peer cluster",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+        },
+    }
+}
+
+// ValidateConfig checks that the token can be decoded
+func (r *rbdMirrorPeerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    var config RBDMirrorPeerResourceModel
+
+    resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if !isKnown(config.Token) {
+        return
+    }
+
+    if _, err := parseRBDMirrorBootstrapToken(config.Token.ValueString()); err != nil {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("token"),
+            "Invalid Bootstrap Token",
+            err.Error(),
+        )
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdMirrorPeerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdMirrorPeerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDMirrorPeerResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // The token was checked by ValidateConfig
+    pool := plan.Pool.ValueString()
+    token, _ := parseRBDMirrorBootstrapToken(plan.Token.ValueString())
+
+    // The peer is found by the monitors it connects to, as the import does
+    // not return it. An existing peer is adopted rather than added twice.
+    peer, err := r.client.FindRBDMirrorPeer(ctx, pool, token)
+    if errors.Is(err, errRBDMirrorPeerNotFound) {
+        err = r.client.ImportRBDMirrorBootstrapToken(ctx, pool, plan.Direction.ValueString(), plan.Token.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Creating Ceph RBD Mirror Peer",
+                fmt.Sprintf("Could not import mirror bootstrap token into pool %s: %s", pool, err.Error()),
+            )
+            return
+        }
+        peer, err = r.client.FindRBDMirrorPeer(ctx, pool, token)
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph RBD Mirror Peer",
+            fmt.Sprintf("Could not find mirror peer %s of pool %s: %s", token.MonHost, pool, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDMirrorPeer(pool, peer)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdMirrorPeerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDMirrorPeerResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    peer, err := r.client.GetRBDMirrorPeer(ctx, pool, state.UUID.ValueString())
+    if err != nil {
+        // If the peer is not found, remove it from state
+        if errors.Is(err, errRBDMirrorPeerNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Mirror Peer",
+            fmt.Sprintf("Could not read mirror peer %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+
+    state.setRBDMirrorPeer(pool, peer)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement
+func (r *rbdMirrorPeerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "RBD Mirror Peers Cannot Be Updated",
+        "RBD mirror peers are immutable and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdMirrorPeerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDMirrorPeerResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Only the local peer is removed; with rx-tx the other cluster keeps its
+    // peer pointing back here
+    err := r.client.DeleteRBDMirrorPeer(ctx, state.Pool.ValueString(), state.UUID.ValueString())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Mirror Peer",
+            fmt.Sprintf("Could not delete mirror peer %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdMirrorPoolResource{}
+    _ resource.ResourceWithConfigure   = &rbdMirrorPoolResource{}
+    _ resource.ResourceWithImportState = &rbdMirrorPoolResource{}
+)
+
+// NewRBDMirrorPoolResource is a helper function to simplify the provider implementation
+func NewRBDMirrorPoolResource() resource.Resource {
+    return &rbdMirrorPoolResource{}
+}
+
+// rbdMirrorPoolResource is the resource implementation
+type rbdMirrorPoolResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdMirrorPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_mirror_pool"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdMirrorPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages the RBD mirror mode of a pool. Destroying the resource disables mirroring of the pool.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Name of the pool",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "mirror_mode": schema.StringAttribute{
+                Description: "Mirror mode of the pool: image to mirror the images mirroring is enabled for, e.g. with ceph_rbd_mirror_image, pool to mirror every image with the journaling feature, or disabled",
+                Required:    true,
+                Validators: []validator.String{
+                    stringvalidator.OneOf(rbdMirrorModeImage, rbdMirrorModePool, rbdMirrorModeDisabled),
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdMirrorPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdMirrorPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDMirrorPoolResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := plan.Pool.ValueString()
+    err := r.client.SetRBDMirrorPoolMode(ctx, pool, plan.MirrorMode.ValueString())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Configuring Ceph RBD Pool Mirroring",
+            fmt.Sprintf("Could not set mirror mode of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    plan.ID = types.StringValue(pool)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdMirrorPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDMirrorPoolResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    mode, err := r.client.GetRBDMirrorPoolMode(ctx, pool)
+    if err != nil {
+        // If the pool is not found, remove it from state
+        if errors.Is(err, errPoolNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Pool Mirroring",
+            fmt.Sprintf("Could not read mirror mode of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    state.ID = types.StringValue(pool)
+    state.MirrorMode = types.StringValue(mode)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdMirrorPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDMirrorPoolResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := plan.Pool.ValueString()
+    err := r.client.SetRBDMirrorPoolMode(ctx, pool, plan.MirrorMode.ValueString())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Configuring Ceph RBD Pool Mirroring",
+            fmt.Sprintf("Could not set mirror mode of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdMirrorPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDMirrorPoolResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    err := r.client.SetRBDMirrorPoolMode(ctx, pool, rbdMirrorModeDisabled)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Disabling Ceph RBD Pool Mirroring",
+            fmt.Sprintf("Could not disable mirroring of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *rbdMirrorPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Use the ID (pool name) as the import identifier
+    resource.ImportStatePassthroughID(ctx, path.Root("pool"), req, resp)
+}
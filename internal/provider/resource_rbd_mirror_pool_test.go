@@ -0,0 +1,142 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD mirror pool resource
+func TestAccRBDMirrorPoolResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestRBDMirrorPoolResourceMirrorMode tests that only the pool mirror modes
+// the Dashboard accepts are valid
+func TestRBDMirrorPoolResourceMirrorMode(t *testing.T) {
+    var schemaResp resource.SchemaResponse
+    (&rbdMirrorPoolResource{}).Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+    attr := schemaResp.Schema.Attributes["mirror_mode"].(schema.StringAttribute)
+    
+    tests := map[string]bool{
+        "image":    true,
+        "pool":     true,
+        "disabled": true,
+        "snapshot": false,
+        "Image":    false,
+        "":         false,
+    }
+    
+    for mode, valid := range tests {
+        t.Run(mode, func(t *testing.T) {
+            req := validator.StringRequest{Path: path.Root("mirror_mode"), ConfigValue: types.StringValue(mode)}
+            var resp validator.StringResponse
+            for _, v := range attr.Validators {
+                v.ValidateString(context.Background(), req, &resp)
+            }
+            
+            if resp.Diagnostics.HasError() == valid {
+                t.Errorf("Expected mirror mode %q valid %t, got: %v", mode, valid, resp.Diagnostics)
+            }
+        })
+    }
+}
+
+// TestRBDMirrorPoolResourceApply tests that creating and updating set the
+// planned mirror mode and that deleting disables mirroring
+func TestRBDMirrorPoolResourceApply(t *testing.T) {
+    var modes []string
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != http.MethodPut || r.URL.Path != "/api/block/mirroring/pool/rbd" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        var body struct {
+            MirrorMode string `json:"mirror_mode"`
+        }
+        json.NewDecoder(r.Body).Decode(&body)
+        modes = append(modes, body.MirrorMode)
+    }))
+    defer server.Close()
+    
+    r := &rbdMirrorPoolResource{client: newTestRetryClient(server)}
+    s, image := testResourceValue(t, r, map[string]tftypes.Value{
+        "id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+        "pool":        tftypes.NewValue(tftypes.String, "rbd"),
+        "mirror_mode": tftypes.NewValue(tftypes.String, "image"),
+    })
+    _, pool := testResourceValue(t, r, map[string]tftypes.Value{
+        "id":          tftypes.NewValue(tftypes.String, "rbd"),
+        "pool":        tftypes.NewValue(tftypes.String, "rbd"),
+        "mirror_mode": tftypes.NewValue(tftypes.String, "pool"),
+    })
+    
+    createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(image.Type(), nil)}}
+    r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: image}}, &createResp)
+    if createResp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected create errors: %v", createResp.Diagnostics)
+    }
+    var state RBDMirrorPoolResourceModel
+    createResp.Diagnostics.Append(createResp.State.Get(context.Background(), &state)...)
+    if state.ID.ValueString() != "rbd" {
+        t.Errorf("Expected id rbd, got %s", state.ID)
+    }
+    
+    updateResp := resource.UpdateResponse{State: createResp.State}
+    r.Update(context.Background(), resource.UpdateRequest{
+        Plan:  tfsdk.Plan{Schema: s, Raw: pool},
+        State: createResp.State,
+    }, &updateResp)
+    if updateResp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected update errors: %v", updateResp.Diagnostics)
+    }
+    
+    var deleteResp resource.DeleteResponse
+    r.Delete(context.Background(), resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+    if deleteResp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected delete errors: %v", deleteResp.Diagnostics)
+    }
+    
+    want := []string{"image", "pool", "disabled"}
+    if !reflect.DeepEqual(modes, want) {
+        t.Errorf("Expected mirror modes %v, got %v", want, modes)
+    }
+}
+
+// TestRBDMirrorPoolResourceReadPoolGone tests that the resource is removed
+// from the state once its pool is gone
+func TestRBDMirrorPoolResourceReadPoolGone(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusNotFound)
+    }))
+    defer server.Close()
+    
+    r := &rbdMirrorPoolResource{client: newTestRetryClient(server)}
+    s, state := testResourceValue(t, r, map[string]tftypes.Value{
+        "id":          tftypes.NewValue(tftypes.String, "rbd"),
+        "pool":        tftypes.NewValue(tftypes.String, "rbd"),
+        "mirror_mode": tftypes.NewValue(tftypes.String, "image"),
+    })
+    
+    resp := resource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state}}
+    r.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: state}}, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    if !resp.State.Raw.IsNull() {
+        t.Errorf("Expected the resource to be removed from the state")
+    }
+}
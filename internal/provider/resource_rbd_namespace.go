@@ -0,0 +1,222 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdNamespaceResource{}
+    _ resource.ResourceWithConfigure   = &rbdNamespaceResource{}
+    _ resource.ResourceWithImportState = &rbdNamespaceResource{}
+)
+
+// NewRBDNamespaceResource is a helper function to simplify the provider implementation
+func NewRBDNamespaceResource() resource.Resource {
+    return &rbdNamespaceResource{}
+}
+
+// rbdNamespaceResource is the resource implementation
+type rbdNamespaceResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdNamespaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_namespace"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdNamespaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages an RBD namespace, which isolates images within a pool. Namespaces that still contain images are not deleted.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Namespace identifier, pool/namespace",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool holding the namespace",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "namespace": schema.StringAttribute{
+                Description: "Name of the namespace",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+                Validators: []validator.String{
+                    stringvalidator.LengthAtLeast(1),
+                },
+            },
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdNamespaceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdNamespaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDNamespaceResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := plan.Pool.ValueString()
+    namespace := plan.Namespace.ValueString()
+    id := pool + "/" + namespace
+
+    err := r.client.CreateRBDNamespace(ctx, pool, namespace)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph RBD Namespace",
+            fmt.Sprintf("Could not create RBD namespace %s: %s", id, err.Error()),
+        )
+        return
+    }
+
+    plan.ID = types.StringValue(id)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdNamespaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDNamespaceResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    namespace := state.Namespace.ValueString()
+    _, err := r.client.GetRBDNamespace(ctx, pool, namespace)
+    if err != nil {
+        // If the namespace is not found, remove it from state
+        if errors.Is(err, errRBDNamespaceNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Namespace",
+            fmt.Sprintf("Could not read RBD namespace %s: %s", state.ID.ValueString(), err.Error()),
+        )
+        return
+    }
+
+    state.ID = types.StringValue(pool + "/" + namespace)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement
+func (r *rbdNamespaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    resp.Diagnostics.AddError(
+        "RBD Namespaces Cannot Be Updated",
+        "RBD namespaces cannot be changed and must be replaced. Please report this issue to the provider developers.",
+    )
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdNamespaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDNamespaceResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    namespace := state.Namespace.ValueString()
+    id := state.ID.ValueString()
+
+    // Images managed in the same configuration are destroyed first, as they
+    // depend on the namespace; any image left blocks the deletion
+    ns, err := r.client.GetRBDNamespace(ctx, pool, namespace)
+    if errors.Is(err, errRBDNamespaceNotFound) {
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Namespace",
+            fmt.Sprintf("Could not read RBD namespace %s: %s", id, err.Error()),
+        )
+        return
+    }
+
+    if ns.NumImages > 0 {
+        resp.Diagnostics.AddError(
+            "RBD Namespace Not Empty",
+            fmt.Sprintf("Cannot delete RBD namespace %s while it contains %d images. Remove the images first.", id, ns.NumImages),
+        )
+        return
+    }
+
+    err = r.client.DeleteRBDNamespace(ctx, pool, namespace)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Namespace",
+            fmt.Sprintf("Could not delete RBD namespace %s: %s", id, err.Error()),
+        )
+        return
+    }
+}
+
+// ImportState imports the resource state
+func (r *rbdNamespaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    pool, namespace, found := strings.Cut(req.ID, "/")
+    if !found || pool == "" || namespace == "" || strings.Contains(namespace, "/") {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import RBD namespace: expected pool/namespace, got %q", req.ID),
+        )
+        return
+    }
+
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pool"), pool)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("namespace"), namespace)...)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdPoolConfigResource{}
+    _ resource.ResourceWithConfigure   = &rbdPoolConfigResource{}
+    _ resource.ResourceWithImportState = &rbdPoolConfigResource{}
+)
+
+// NewRBDPoolConfigResource is a helper function to simplify the provider implementation
+func NewRBDPoolConfigResource() resource.Resource {
+    return &rbdPoolConfigResource{}
+}
+
+// rbdPoolConfigResource is the resource implementation
+type rbdPoolConfigResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdPoolConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_pool_config"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdPoolConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages RBD configuration overrides of a pool, which its images inherit unless they override them. Destroying the resource removes the overrides.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Name of the pool",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "pool": schema.StringAttribute{
+                Description: "Name of the pool",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+        },
+        Blocks: map[string]schema.Block{
+            "qos": rbdQoSBlock("pool"),
+        },
+    }
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdPoolConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdPoolConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDPoolConfigResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Overrides set before, e.g. by hand, are replaced by the configured ones
+    pool := plan.Pool.ValueString()
+    options, err := r.client.GetPoolRBDConfiguration(ctx, pool)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Pool Configuration",
+            fmt.Sprintf("Could not read RBD configuration of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    current := newRBDQoSModel(rbdConfigOverrides(options, rbdConfigSourcePool), nil)
+    r.apply(ctx, pool, &plan, current, &resp.Diagnostics)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdPoolConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDPoolConfigResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    pool := state.Pool.ValueString()
+    options, err := r.client.GetPoolRBDConfiguration(ctx, pool)
+    if err != nil {
+        // If the pool is not found, remove it from state
+        if errors.Is(err, errPoolNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Pool Configuration",
+            fmt.Sprintf("Could not read RBD configuration of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+
+    state.ID = types.StringValue(pool)
+    state.QoS = newRBDQoSModel(rbdConfigOverrides(options, rbdConfigSourcePool), state.QoS)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdPoolConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDPoolConfigResourceModel
+    var state RBDPoolConfigResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    r.apply(ctx, plan.Pool.ValueString(), &plan, state.QoS, &resp.Diagnostics)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdPoolConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDPoolConfigResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Remove the overrides so that the pool inherits the settings again
+    pool := state.Pool.ValueString()
+    configuration := rbdQoSChanges(nil, state.QoS)
+    if len(configuration) == 0 {
+        return
+    }
+
+    err := r.client.SetPoolRBDConfiguration(ctx, pool, configuration)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Pool Configuration",
+            fmt.Sprintf("Could not remove RBD configuration overrides of pool %s: %s", pool, err.Error()),
+        )
+        return
+    }
+}
+
+// apply changes the overrides of the pool from current to those planned
+func (r *rbdPoolConfigResource) apply(ctx context.Context, pool string, plan *RBDPoolConfigResourceModel, current *RBDQoSModel, diags *diag.Diagnostics) {
+    if configuration := rbdQoSChanges(plan.QoS, current); len(configuration) > 0 {
+        err := r.client.SetPoolRBDConfiguration(ctx, pool, configuration)
+        if err != nil {
+            diags.AddError(
+                "Error Configuring Ceph RBD Pool",
+                fmt.Sprintf("Could not set RBD configuration of pool %s: %s", pool, err.Error()),
+            )
+            return
+        }
+    }
+
+    plan.ID = types.StringValue(pool)
+}
+
+// ImportState imports the resource state
+func (r *rbdPoolConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Use the ID (pool name) as the import identifier
+    resource.ImportStatePassthroughID(ctx, path.Root("pool"), req, resp)
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD pool config resource
+func TestAccRBDPoolConfigResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// newTestPoolConfigServer serves the RBD configuration of pool rbd, which
+// overrides rbd_qos_bps_limit and inherits rbd_qos_read_iops_limit, and
+// records the configuration changes sent
+func newTestPoolConfigServer(t *testing.T, changes *[]string) *httptest.Server {
+    return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch {
+        case r.Method == "GET" && r.URL.Path == "/api/pool/rbd/configuration":
+            json.NewEncoder(w).Encode([]RBDConfigOption{
+                {Name: "rbd_qos_bps_limit", Value: "1000", Source: rbdConfigSourcePool},
+                {Name: "rbd_qos_read_iops_limit", Value: "500", Source: rbdConfigSourceConfig},
+            })
+        case r.Method == "PATCH" && r.URL.Path == "/api/pool/rbd":
+            var body map[string]interface{}
+            json.NewDecoder(r.Body).Decode(&body)
+            change, _ := json.Marshal(body["configuration"])
+            *changes = append(*changes, string(change))
+        default:
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+        }
+    }))
+}
+
+// testPoolConfigValue returns the resource value for pool rbd with an
+// iops_limit override of 100
+func testPoolConfigValue(t *testing.T, r *rbdPoolConfigResource) (tfsdk.State, tfsdk.Plan) {
+    s, raw := testResourceValue(t, r, nil)
+    qosType := raw.Type().(tftypes.Object).AttributeTypes["qos"].(tftypes.Object)
+    
+    qos := make(map[string]tftypes.Value, len(qosType.AttributeTypes))
+    for name, attrType := range qosType.AttributeTypes {
+        qos[name] = tftypes.NewValue(attrType, nil)
+    }
+    qos["iops_limit"] = tftypes.NewValue(tftypes.Number, 100)
+    
+    _, raw = testResourceValue(t, r, map[string]tftypes.Value{
+        "id":   tftypes.NewValue(tftypes.String, "rbd"),
+        "pool": tftypes.NewValue(tftypes.String, "rbd"),
+        "qos":  tftypes.NewValue(qosType, qos),
+    })
+    return tfsdk.State{Schema: s, Raw: raw}, tfsdk.Plan{Schema: s, Raw: raw}
+}
+
+// TestRBDPoolConfigResourceCreate tests that overrides set before are
+// replaced by the configured ones, leaving inherited options alone
+func TestRBDPoolConfigResourceCreate(t *testing.T) {
+    var changes []string
+    server := newTestPoolConfigServer(t, &changes)
+    defer server.Close()
+    
+    r := &rbdPoolConfigResource{client: newTestRetryClient(server)}
+    state, plan := testPoolConfigValue(t, r)
+    
+    resp := resource.CreateResponse{State: tfsdk.State{Schema: state.Schema, Raw: tftypes.NewValue(state.Raw.Type(), nil)}}
+    r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    want := []string{`{"rbd_qos_bps_limit":null,"rbd_qos_iops_limit":100}`}
+    if !reflect.DeepEqual(changes, want) {
+        t.Errorf("Expected changes %v, got %v", want, changes)
+    }
+}
+
+// TestRBDPoolConfigResourceDelete tests that destroying the resource only
+// removes the overrides it manages
+func TestRBDPoolConfigResourceDelete(t *testing.T) {
+    var changes []string
+    server := newTestPoolConfigServer(t, &changes)
+    defer server.Close()
+    
+    r := &rbdPoolConfigResource{client: newTestRetryClient(server)}
+    state, _ := testPoolConfigValue(t, r)
+    
+    resp := resource.DeleteResponse{State: state}
+    r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+    
+    if resp.Diagnostics.HasError() {
+        t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+    }
+    want := []string{`{"rbd_qos_iops_limit":null}`}
+    if !reflect.DeepEqual(changes, want) {
+        t.Errorf("Expected changes %v, got %v", want, changes)
+    }
+}
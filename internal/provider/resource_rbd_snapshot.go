@@ -0,0 +1,526 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "regexp"
+    "strings"
+    "time"
+
+    "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+    "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts for RBD snapshot operations that wait on Dashboard background tasks
+const (
+    defaultRBDSnapshotCreateTimeout = 5 * time.Minute
+    defaultRBDSnapshotUpdateTimeout = 5 * time.Minute
+    defaultRBDSnapshotDeleteTimeout = 10 * time.Minute
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+    _ resource.Resource                = &rbdSnapshotResource{}
+    _ resource.ResourceWithConfigure   = &rbdSnapshotResource{}
+    _ resource.ResourceWithImportState = &rbdSnapshotResource{}
+    _ resource.ResourceWithModifyPlan  = &rbdSnapshotResource{}
+)
+
+// NewRBDSnapshotResource is a helper function to simplify the provider implementation
+func NewRBDSnapshotResource() resource.Resource {
+    return &rbdSnapshotResource{}
+}
+
+// rbdSnapshotResource is the resource implementation
+type rbdSnapshotResource struct {
+    client *CephClient
+}
+
+// Metadata returns the resource type name
+func (r *rbdSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_rbd_snapshot"
+}
+
+// Schema defines the schema for the resource
+func (r *rbdSnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a snapshot of a Ceph RBD image. Destroying the snapshot removes it without rolling the image back; see rollback_trigger to roll back.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Description: "Snapshot spec, pool/namespace/image@snapshot or pool/image@snapshot outside of a namespace",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "image": schema.StringAttribute{
+                Description: "Spec of the image to snapshot, e.g. the id of a ceph_rbd_image. Changing it replaces the snapshot unless the image was renamed.",
+                Required:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplaceIf(
+                        r.requiresReplaceImage,
+                        "Changing the image replaces the snapshot unless the image was renamed.",
+                        "Changing the image replaces the snapshot unless the image was renamed.",
+                    ),
+                },
+                Validators: []validator.String{
+                    rbdImageSpecValidator{},
+                },
+            },
+            "name": schema.StringAttribute{
+                Description: "Name of the snapshot. Changing it renames the snapshot in place.",
+                Required:    true,
+                Validators: []validator.String{
+                    stringvalidator.RegexMatches(regexp.MustCompile(`^[^/@]+$`), "must not be empty or contain / or @"),
+                },
+            },
+            "protected": schema.BoolAttribute{
+                Description: "Protect the snapshot from deletion, which clones of it require unless the cluster supports clone v2. A snapshot cannot be unprotected while it has clones.",
+                Optional:    true,
+                Computed:    true,
+                Default:     booldefault.StaticBool(false),
+            },
+            "rollback_trigger": schema.StringAttribute{
+                Description: "Any value, changing it rolls the image back to the snapshot, discarding the data written since. Setting it when the snapshot is created or removing it does not roll back, and destroying the snapshot never does. Stop the image's users first.",
+                Optional:    true,
+            },
+            "snap_id": schema.Int64Attribute{
+                Description: "Numeric id of the snapshot",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                },
+            },
+            "size": schema.Int64Attribute{
+                Description: "Size of the image in bytes when the snapshot was taken",
+                Computed:    true,
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.UseStateForUnknown(),
+                },
+            },
+            "created": schema.StringAttribute{
+                Description: "Time the snapshot was taken",
+                Computed:    true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "children": schema.SetAttribute{
+                Description: "Specs of the images cloned from the snapshot. The snapshot cannot be removed or unprotected while it has clones.",
+                ElementType: types.StringType,
+                Computed:    true,
+            },
+        },
+        Blocks: map[string]schema.Block{
+            "timeouts": timeouts.Block(ctx, timeouts.Opts{
+                Create: true,
+                Update: true,
+                Delete: true,
+            }),
+        },
+    }
+}
+
+// requiresReplaceImage replaces the snapshot when it moves to a different
+// image, but not when its image is renamed and still has the snapshot
+func (r *rbdSnapshotResource) requiresReplaceImage(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+    var snapID types.Int64
+    var name, created types.String
+    resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("snap_id"), &snapID)...)
+    resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+    resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("created"), &created)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    requiresReplaceRenamedImage(ctx, r.client, req, resp, func(image *RBDImage) bool {
+        return sameRBDSnapshot(image, name.ValueString(), snapID, created)
+    })
+}
+
+// sameRBDSnapshot reports whether the image has the snapshot with the given
+// name, id and creation time. Snapshot ids are only unique within an image.
+func sameRBDSnapshot(image *RBDImage, name string, snapID types.Int64, created types.String) bool {
+    for _, snapshot := range image.Snapshots {
+        if snapshot.Name == name {
+            return snapshot.ID == snapID.ValueInt64() && snapshot.Timestamp == created.ValueString()
+        }
+    }
+    return false
+}
+
+// ModifyPlan plans the new id when the snapshot or its image is renamed and
+// warns about planned rollbacks
+func (r *rbdSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    // Nothing to check when the snapshot is created or destroyed
+    if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+        return
+    }
+
+    var plan, state RBDSnapshotResourceModel
+
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // Plan the renamed spec rather than an unknown id, which would make
+    // clones referencing the id plan a replacement
+    if !plan.Name.Equal(state.Name) || !plan.Image.Equal(state.Image) {
+        id := types.StringUnknown()
+        if isKnown(plan.Image) && isKnown(plan.Name) {
+            id = types.StringValue(rbdSnapshotSpec(plan.Image.ValueString(), plan.Name.ValueString()))
+        }
+        resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), id)...)
+    }
+
+    if rollbackTriggered(plan, state) {
+        resp.Diagnostics.AddAttributeWarning(
+            path.Root("rollback_trigger"),
+            "RBD Image Will Be Rolled Back",
+            fmt.Sprintf("Applying this plan rolls image %s back to snapshot %s, discarding the data written to it since.", state.Image.ValueString(), state.Name.ValueString()),
+        )
+    }
+}
+
+// rollbackTriggered reports whether the plan changes rollback_trigger to a
+// new value, which rolls the image back to the snapshot
+func rollbackTriggered(plan, state RBDSnapshotResourceModel) bool {
+    return !plan.RollbackTrigger.IsNull() && !plan.RollbackTrigger.Equal(state.RollbackTrigger)
+}
+
+// Configure adds the provider configured client to the resource
+func (r *rbdSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*CephClient)
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Resource Configure Type",
+            fmt.Sprintf("Expected *CephClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+        return
+    }
+
+    r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *rbdSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan RBDSnapshotResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    createTimeout, diags := plan.Timeouts.Create(ctx, defaultRBDSnapshotCreateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, createTimeout)
+    defer cancel()
+
+    imageSpec := plan.Image.ValueString()
+    name := plan.Name.ValueString()
+    spec := rbdSnapshotSpec(imageSpec, name)
+
+    err := r.client.CreateRBDSnapshot(ctx, imageSpec, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Creating Ceph RBD Snapshot",
+            fmt.Sprintf("Could not create RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Snapshots are created unprotected
+    if plan.Protected.ValueBool() {
+        err = r.client.UpdateRBDSnapshot(ctx, imageSpec, name, map[string]interface{}{"is_protected": true})
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Protecting Ceph RBD Snapshot",
+                fmt.Sprintf("Could not protect RBD snapshot %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    snapshot, err := r.client.GetRBDSnapshot(ctx, imageSpec, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Created Ceph RBD Snapshot",
+            fmt.Sprintf("Could not read created RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDSnapshot(imageSpec, snapshot)
+
+    // Save data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *rbdSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state RBDSnapshotResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // The spec was checked when the snapshot was created or imported
+    spec := state.ID.ValueString()
+    imageSpec, name, _ := parseRBDSnapshotSpec(spec)
+
+    // Get snapshot information from Ceph
+    snapshot, err := r.client.GetRBDSnapshot(ctx, imageSpec, name)
+    if err != nil {
+        // If the snapshot is not found, remove it from state
+        if errors.Is(err, errRBDSnapshotNotFound) {
+            resp.State.RemoveResource(ctx)
+            return
+        }
+
+        resp.Diagnostics.AddError(
+            "Error Reading Ceph RBD Snapshot",
+            fmt.Sprintf("Could not read RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    // Update the state with the latest data
+    state.setRBDSnapshot(imageSpec, snapshot)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *rbdSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan RBDSnapshotResourceModel
+    var state RBDSnapshotResourceModel
+
+    // Read Terraform plan data into the model
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    updateTimeout, diags := plan.Timeouts.Update(ctx, defaultRBDSnapshotUpdateTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+    defer cancel()
+
+    imageSpec := state.Image.ValueString()
+    name := state.Name.ValueString()
+    spec := state.ID.ValueString()
+
+    // An image change that was not planned as a replacement must be a rename
+    // of the snapshot's image, which has happened by now
+    if plan.Image.ValueString() != imageSpec {
+        image, err := r.client.GetRBDImage(ctx, plan.Image.ValueString())
+        if err != nil && !errors.Is(err, errRBDImageNotFound) {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Snapshot",
+                fmt.Sprintf("Could not read RBD image %s: %s", plan.Image.ValueString(), err.Error()),
+            )
+            return
+        }
+        if err != nil || !sameRBDSnapshot(image, name, state.SnapID, state.Created) {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("image"),
+                "RBD Snapshot Image Changed",
+                fmt.Sprintf("RBD image %s does not have the snapshot %s, so it is not %s renamed. Changing the image replaces the snapshot; run terraform plan again to plan the replacement.", plan.Image.ValueString(), name, imageSpec),
+            )
+            return
+        }
+        imageSpec = plan.Image.ValueString()
+        spec = rbdSnapshotSpec(imageSpec, name)
+    }
+
+    // Check the clones right before unprotecting, the state may be stale.
+    // This happens before the rename so that a refusal changes nothing.
+    changes := map[string]interface{}{}
+    if plan.Protected.ValueBool() != state.Protected.ValueBool() {
+        if !plan.Protected.ValueBool() {
+            snapshot, err := r.client.GetRBDSnapshot(ctx, imageSpec, name)
+            if err != nil {
+                resp.Diagnostics.AddError(
+                    "Error Updating Ceph RBD Snapshot",
+                    fmt.Sprintf("Could not read RBD snapshot %s: %s", spec, err.Error()),
+                )
+                return
+            }
+            addClonesError(&resp.Diagnostics, "unprotect", spec, snapshot)
+            if resp.Diagnostics.HasError() {
+                return
+            }
+        }
+        changes["is_protected"] = plan.Protected.ValueBool()
+    }
+
+    // Rename the snapshot first, the remaining changes address it by name
+    if plan.Name.ValueString() != name {
+        err := r.client.RenameRBDSnapshot(ctx, imageSpec, name, plan.Name.ValueString())
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Renaming Ceph RBD Snapshot",
+                fmt.Sprintf("Could not rename RBD snapshot %s to %s: %s", spec, plan.Name.ValueString(), err.Error()),
+            )
+            return
+        }
+        name = plan.Name.ValueString()
+        spec = rbdSnapshotSpec(imageSpec, name)
+    }
+
+    if len(changes) > 0 {
+        err := r.client.UpdateRBDSnapshot(ctx, imageSpec, name, changes)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Updating Ceph RBD Snapshot",
+                fmt.Sprintf("Could not update RBD snapshot %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    // Roll back last, on the snapshot as renamed. Create and Delete never
+    // roll back.
+    if rollbackTriggered(plan, state) {
+        err := r.client.RollbackRBDSnapshot(ctx, imageSpec, name)
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Rolling Back Ceph RBD Image",
+                fmt.Sprintf("Could not roll image %s back to snapshot %s: %s", imageSpec, spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    snapshot, err := r.client.GetRBDSnapshot(ctx, imageSpec, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Reading Updated Ceph RBD Snapshot",
+            fmt.Sprintf("Could not read updated RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    plan.setRBDSnapshot(imageSpec, snapshot)
+
+    // Save updated data into Terraform state
+    resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *rbdSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state RBDSnapshotResourceModel
+
+    // Read Terraform prior state data into the model
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultRBDSnapshotDeleteTimeout)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+    defer cancel()
+
+    imageSpec := state.Image.ValueString()
+    name := state.Name.ValueString()
+    spec := state.ID.ValueString()
+
+    // Clones managed in the same configuration are destroyed first, as
+    // they depend on the snapshot; any clone left blocks the deletion
+    snapshot, err := r.client.GetRBDSnapshot(ctx, imageSpec, name)
+    if errors.Is(err, errRBDSnapshotNotFound) {
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Snapshot",
+            fmt.Sprintf("Could not read RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+
+    addClonesError(&resp.Diagnostics, "delete", spec, snapshot)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if snapshot.IsProtected {
+        err = r.client.UpdateRBDSnapshot(ctx, imageSpec, name, map[string]interface{}{"is_protected": false})
+        if err != nil {
+            resp.Diagnostics.AddError(
+                "Error Unprotecting Ceph RBD Snapshot",
+                fmt.Sprintf("Could not unprotect RBD snapshot %s: %s", spec, err.Error()),
+            )
+            return
+        }
+    }
+
+    err = r.client.DeleteRBDSnapshot(ctx, imageSpec, name)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Error Deleting Ceph RBD Snapshot",
+            fmt.Sprintf("Could not delete RBD snapshot %s: %s", spec, err.Error()),
+        )
+        return
+    }
+}
+
+// addClonesError adds an error diagnostic when the snapshot has clones,
+// which block removing or unprotecting it
+func addClonesError(diags *diag.Diagnostics, action, spec string, snapshot *RBDSnapshot) {
+    if len(snapshot.Children) == 0 {
+        return
+    }
+    diags.AddError(
+        "RBD Snapshot Has Clones",
+        fmt.Sprintf("Cannot %s RBD snapshot %s while it has clones: %s. Remove or flatten the clones first.", action, spec, strings.Join(snapshot.ChildSpecs(), ", ")),
+    )
+}
+
+// ImportState imports the resource state
+func (r *rbdSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    // Snapshots are imported by spec, Read fills in the rest
+    if _, _, err := parseRBDSnapshotSpec(req.ID); err != nil {
+        resp.Diagnostics.AddError(
+            "Invalid Import ID",
+            fmt.Sprintf("Could not import RBD snapshot: %s", err.Error()),
+        )
+        return
+    }
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
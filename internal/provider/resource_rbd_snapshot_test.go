@@ -0,0 +1,253 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Placeholder test for RBD snapshot resource
+func TestAccRBDSnapshotResource(t *testing.T) {
+    t.Skip("Acceptance tests require a running Ceph cluster")
+}
+
+// TestRollbackTriggered tests that only a new rollback_trigger value rolls
+// the image back
+func TestRollbackTriggered(t *testing.T) {
+    tests := []struct {
+        name  string
+        state types.String
+        plan  types.String
+        want  bool
+    }{
+        {name: "unset", state: types.StringNull(), plan: types.StringNull()},
+        {name: "unchanged", state: types.StringValue("1"), plan: types.StringValue("1")},
+        {name: "removed", state: types.StringValue("1"), plan: types.StringNull()},
+        {name: "set", state: types.StringNull(), plan: types.StringValue("1"), want: true},
+        {name: "changed", state: types.StringValue("1"), plan: types.StringValue("2"), want: true},
+        {name: "unknown", state: types.StringValue("1"), plan: types.StringUnknown(), want: true},
+    }
+    
+    for _, tt := range tests {
+        plan := RBDSnapshotResourceModel{RollbackTrigger: tt.plan}
+        state := RBDSnapshotResourceModel{RollbackTrigger: tt.state}
+        if got := rollbackTriggered(plan, state); got != tt.want {
+            t.Errorf("%s: expected %t, got %t", tt.name, tt.want, got)
+        }
+    }
+}
+
+// TestAddClonesError tests that snapshots with clones are reported with them
+func TestAddClonesError(t *testing.T) {
+    var diags diag.Diagnostics
+    addClonesError(&diags, "delete", "rbd/golden@base", &RBDSnapshot{Name: "base"})
+    if diags.HasError() {
+        t.Fatalf("Expected no error without clones, got %v", diags)
+    }
+    
+    addClonesError(&diags, "delete", "rbd/golden@base", &RBDSnapshot{
+        Name:     "base",
+        Children: []RBDImageRef{{PoolName: "rbd", ImageName: "vm-1"}, {PoolName: "rbd", PoolNamespace: "tenant", ImageName: "vm-2"}},
+    })
+    if diags.ErrorsCount() != 1 {
+        t.Fatalf("Expected 1 error, got %v", diags)
+    }
+    if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "rbd/vm-1, rbd/tenant/vm-2") {
+        t.Errorf("Expected the clones to be listed, got %q", detail)
+    }
+}
+
+// newTestSnapshotWithClonesServer serves a protected snapshot rbd/golden@base
+// with a clone, failing the test on any request that would change it
+func newTestSnapshotWithClonesServer(t *testing.T) *httptest.Server {
+    return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.Method != "GET" || r.URL.Path != "/api/block/image/rbd/golden" {
+            t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        json.NewEncoder(w).Encode(RBDImage{
+            Name:     "golden",
+            PoolName: "rbd",
+            Snapshots: []RBDSnapshot{{
+                ID:          4,
+                Name:        "base",
+                IsProtected: true,
+                Children:    []RBDImageRef{{PoolName: "rbd", ImageName: "vm-1"}},
+            }},
+        })
+    }))
+}
+
+// testRBDSnapshotState returns the state of rbd/golden@base
+func testRBDSnapshotState(t *testing.T, r *rbdSnapshotResource, protected bool) tfsdk.State {
+    s, raw := testResourceValue(t, r, map[string]tftypes.Value{
+        "id":        tftypes.NewValue(tftypes.String, "rbd/golden@base"),
+        "image":     tftypes.NewValue(tftypes.String, "rbd/golden"),
+        "name":      tftypes.NewValue(tftypes.String, "base"),
+        "protected": tftypes.NewValue(tftypes.Bool, protected),
+    })
+    return tfsdk.State{Schema: s, Raw: raw}
+}
+
+// TestRBDSnapshotResourceDeleteWithClones tests that a snapshot with clones
+// is neither unprotected nor deleted
+func TestRBDSnapshotResourceDeleteWithClones(t *testing.T) {
+    server := newTestSnapshotWithClonesServer(t)
+    defer server.Close()
+    
+    r := &rbdSnapshotResource{client: newTestRetryClient(server)}
+    state := testRBDSnapshotState(t, r, true)
+    
+    resp := resource.DeleteResponse{State: state}
+    r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+    
+    if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "RBD Snapshot Has Clones" {
+        t.Errorf("Expected the clones error, got %v", resp.Diagnostics)
+    }
+}
+
+// TestRBDSnapshotResourceUnprotectWithClones tests that a snapshot with
+// clones is not unprotected
+func TestRBDSnapshotResourceUnprotectWithClones(t *testing.T) {
+    server := newTestSnapshotWithClonesServer(t)
+    defer server.Close()
+    
+    r := &rbdSnapshotResource{client: newTestRetryClient(server)}
+    state := testRBDSnapshotState(t, r, true)
+    plan := testRBDSnapshotState(t, r, false)
+    
+    resp := resource.UpdateResponse{State: state}
+    r.Update(context.Background(), resource.UpdateRequest{
+        Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+        State: state,
+    }, &resp)
+    
+    if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "RBD Snapshot Has Clones" {
+        t.Errorf("Expected the clones error, got %v", resp.Diagnostics)
+    }
+}
+
+// TestRBDSnapshotResourceRequiresReplaceImage tests that changing the image
+// only replaces the snapshot when it is not the snapshot's image renamed
+func TestRBDSnapshotResourceRequiresReplaceImage(t *testing.T) {
+    golden := RBDImage{Name: "golden", PoolName: "rbd", Snapshots: []RBDSnapshot{{ID: 4, Name: "base", Timestamp: "2024-05-01T10:00:00Z"}}}
+    renamed := RBDImage{Name: "golden-2", PoolName: "rbd", Snapshots: golden.Snapshots}
+    other := RBDImage{Name: "golden-2", PoolName: "rbd", Snapshots: []RBDSnapshot{{ID: 4, Name: "base", Timestamp: "2024-06-01T10:00:00Z"}}}
+    
+    tests := map[string]struct {
+        images  map[string]RBDImage
+        want    bool
+        warning bool
+    }{
+        "renamed by this apply": {images: map[string]RBDImage{"rbd/golden": golden}, want: false, warning: true},
+        "renamed":               {images: map[string]RBDImage{"rbd/golden-2": renamed}, want: false},
+        "different image":       {images: map[string]RBDImage{"rbd/golden": golden, "rbd/golden-2": renamed}, want: true},
+        "different snapshot":    {images: map[string]RBDImage{"rbd/golden-2": other}, want: true},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            server := newTestRBDImagesServer(t, tt.images)
+            defer server.Close()
+            
+            r := &rbdSnapshotResource{client: newTestRetryClient(server)}
+            s, state := testResourceValue(t, r, map[string]tftypes.Value{
+                "id":      tftypes.NewValue(tftypes.String, "rbd/golden@base"),
+                "image":   tftypes.NewValue(tftypes.String, "rbd/golden"),
+                "name":    tftypes.NewValue(tftypes.String, "base"),
+                "snap_id": tftypes.NewValue(tftypes.Number, 4),
+                "created": tftypes.NewValue(tftypes.String, "2024-05-01T10:00:00Z"),
+            })
+            
+            req := planmodifier.StringRequest{
+                Path:       path.Root("image"),
+                State:      tfsdk.State{Schema: s, Raw: state},
+                StateValue: types.StringValue("rbd/golden"),
+                PlanValue:  types.StringValue("rbd/golden-2"),
+            }
+            var resp stringplanmodifier.RequiresReplaceIfFuncResponse
+            r.requiresReplaceImage(context.Background(), req, &resp)
+            
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            if resp.RequiresReplace != tt.want {
+                t.Errorf("Expected RequiresReplace %t, got %t", tt.want, resp.RequiresReplace)
+            }
+            if got := resp.Diagnostics.WarningsCount() > 0; got != tt.warning {
+                t.Errorf("Expected warning %t, got %v", tt.warning, resp.Diagnostics)
+            }
+        })
+    }
+}
+
+// TestRBDSnapshotResourceUpdateImageRenamed tests that an in-place image
+// change follows a renamed image and is refused for any other image
+func TestRBDSnapshotResourceUpdateImageRenamed(t *testing.T) {
+    tests := map[string]struct {
+        snapshot  RBDSnapshot
+        wantError bool
+    }{
+        "renamed":        {snapshot: RBDSnapshot{ID: 4, Name: "base", Timestamp: "2024-05-01T10:00:00Z"}},
+        "other snapshot": {snapshot: RBDSnapshot{ID: 9, Name: "base", Timestamp: "2024-06-01T10:00:00Z"}, wantError: true},
+    }
+    
+    for name, tt := range tests {
+        t.Run(name, func(t *testing.T) {
+            server := newTestRBDImagesServer(t, map[string]RBDImage{
+                "rbd/golden-2": {Name: "golden-2", PoolName: "rbd", Snapshots: []RBDSnapshot{tt.snapshot}},
+            })
+            defer server.Close()
+            
+            r := &rbdSnapshotResource{client: newTestRetryClient(server)}
+            values := map[string]tftypes.Value{
+                "id":        tftypes.NewValue(tftypes.String, "rbd/golden@base"),
+                "image":     tftypes.NewValue(tftypes.String, "rbd/golden"),
+                "name":      tftypes.NewValue(tftypes.String, "base"),
+                "protected": tftypes.NewValue(tftypes.Bool, false),
+                "snap_id":   tftypes.NewValue(tftypes.Number, 4),
+                "created":   tftypes.NewValue(tftypes.String, "2024-05-01T10:00:00Z"),
+            }
+            s, state := testResourceValue(t, r, values)
+            values["id"] = tftypes.NewValue(tftypes.String, "rbd/golden-2@base")
+            values["image"] = tftypes.NewValue(tftypes.String, "rbd/golden-2")
+            _, plan := testResourceValue(t, r, values)
+            
+            req := resource.UpdateRequest{
+                Plan:  tfsdk.Plan{Schema: s, Raw: plan},
+                State: tfsdk.State{Schema: s, Raw: state},
+            }
+            resp := resource.UpdateResponse{State: req.State}
+            r.Update(context.Background(), req, &resp)
+            
+            if tt.wantError {
+                if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "RBD Snapshot Image Changed" {
+                    t.Errorf("Expected the image changed error, got %v", resp.Diagnostics)
+                }
+                return
+            }
+            if resp.Diagnostics.HasError() {
+                t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+            }
+            
+            var id types.String
+            resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+            if id.ValueString() != "rbd/golden-2@base" {
+                t.Errorf("Expected id rbd/golden-2@base, got %s", id)
+            }
+        })
+    }
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+    "context"
+    "crypto/tls"
+    "errors"
+    "io"
+    "math/rand"
+    "net"
+    "net/http"
+    "strconv"
+    "syscall"
+    "time"
+)
+
+// Default retry policy values
+const (
+    defaultRetryMaxAttempts = 5
+    defaultRetryMinBackoff  = 1 * time.Second
+    defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// defaultRetryableStatusCodes are the responses a Dashboard typically sends
+// while the active mgr is failing over or restarting
+var defaultRetryableStatusCodes = []int{
+    http.StatusBadGateway,
+    http.StatusServiceUnavailable,
+    http.StatusGatewayTimeout,
+}
+
+// errAlreadyApplied is returned by doRequestVerified when a failed attempt
+// of a non-idempotent request turns out to have taken effect
+var errAlreadyApplied = errors.New("request was already applied")
+
+// RetryPolicy controls how transient Ceph API failures are retried
+type RetryPolicy struct {
+    // MaxAttempts is the total number of attempts, including the first one
+    MaxAttempts int
+
+    // MinBackoff and MaxBackoff bound the exponential backoff between attempts
+    MinBackoff time.Duration
+    MaxBackoff time.Duration
+
+    // RetryableStatusCodes lists the HTTP status codes that are retried
+    RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used unless configured otherwise
+func DefaultRetryPolicy() RetryPolicy {
+    return RetryPolicy{
+        MaxAttempts:          defaultRetryMaxAttempts,
+        MinBackoff:           defaultRetryMinBackoff,
+        MaxBackoff:           defaultRetryMaxBackoff,
+        RetryableStatusCodes: append([]int(nil), defaultRetryableStatusCodes...),
+    }
+}
+
+// shouldRetry reports whether the outcome of an attempt is transient
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+    if err != nil {
+        return isRetryableError(err)
+    }
+
+    for _, code := range p.RetryableStatusCodes {
+        if resp.StatusCode == code {
+            return true
+        }
+    }
+    return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header on resp takes precedence over the exponential backoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+    if resp != nil {
+        if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+            return wait
+        }
+    }
+
+    wait := p.MinBackoff
+    for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+        wait *= 2
+    }
+    if wait > p.MaxBackoff {
+        wait = p.MaxBackoff
+    }
+    if wait <= 0 {
+        return 0
+    }
+
+    // Jitter the upper half so parallel resource operations spread out
+    half := wait / 2
+    return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+    if value == "" {
+        return 0, false
+    }
+
+    if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+        return time.Duration(seconds) * time.Second, true
+    }
+
+    if date, err := http.ParseTime(value); err == nil {
+        wait := time.Until(date)
+        if wait < 0 {
+            wait = 0
+        }
+        return wait, true
+    }
+
+    return 0, false
+}
+
+// isRetryableError reports whether err is a network failure that is likely
+// to go away, such as a reset connection during a mgr failover
+func isRetryableError(err error) bool {
+    var certErr *tls.CertificateVerificationError
+    if errors.As(err, &certErr) {
+        return false
+    }
+
+    var opErr *net.OpError
+    if errors.As(err, &opErr) {
+        return true
+    }
+
+    var netErr net.Error
+    if errors.As(err, &netErr) && netErr.Timeout() {
+        return true
+    }
+
+    return errors.Is(err, io.EOF) ||
+        errors.Is(err, io.ErrUnexpectedEOF) ||
+        errors.Is(err, syscall.ECONNRESET) ||
+        errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isIdempotent reports whether requests with the given method can be sent
+// again without changing their outcome. PUT and PATCH are included because
+// the Ceph API uses them to set absolute values. Renames are the exception:
+// a repeated rename addresses the object by the name it no longer has, so
+// they are sent through doRequestVerified with a check for the new name.
+func isIdempotent(method string) bool {
+    switch method {
+    case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodPatch:
+        return true
+    }
+    return false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+}
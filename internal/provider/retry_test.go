@@ -0,0 +1,164 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+// newTestRetryClient returns a client for server with an already valid token
+// and a retry policy without noticeable backoff
+func newTestRetryClient(server *httptest.Server) *CephClient {
+    client := NewCephClient(server.URL, "admin", "password")
+    client.Token = "token"
+    client.tokenCheckedAt = time.Now()
+    client.Retry.MinBackoff = time.Millisecond
+    client.Retry.MaxBackoff = time.Millisecond
+    return client
+}
+
+// TestRetryTransientStatus tests that idempotent requests are retried on
+// 503 responses until they succeed
+func TestRetryTransientStatus(t *testing.T) {
+    attempts := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        attempts++
+        if attempts < 3 {
+            w.WriteHeader(http.StatusServiceUnavailable)
+            return
+        }
+        json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "test"})
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    if _, err := client.GetPool(context.Background(), "test"); err != nil {
+        t.Fatalf("Expected request to succeed after retries, got: %s", err)
+    }
+    
+    if attempts != 3 {
+        t.Errorf("Expected 3 attempts, got %d", attempts)
+    }
+}
+
+// TestRetryGivesUp tests that retries stop after MaxAttempts
+func TestRetryGivesUp(t *testing.T) {
+    attempts := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        attempts++
+        w.WriteHeader(http.StatusBadGateway)
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    client.Retry.MaxAttempts = 2
+    
+    if _, err := client.GetPool(context.Background(), "test"); err == nil {
+        t.Fatal("Expected request to fail")
+    }
+    
+    if attempts != 2 {
+        t.Errorf("Expected 2 attempts, got %d", attempts)
+    }
+}
+
+// TestRetryCreatePoolVerified tests that a failed pool creation is not sent
+// again once GetPool shows the pool was created
+func TestRetryCreatePoolVerified(t *testing.T) {
+    creates := 0
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/pool", func(w http.ResponseWriter, r *http.Request) {
+        creates++
+        w.WriteHeader(http.StatusGatewayTimeout)
+    })
+    mux.HandleFunc("/api/pool/test", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(map[string]interface{}{"pool_name": "test"})
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    if err := client.CreatePool(context.Background(), PoolCreateRequest{Pool: "test"}); err != nil {
+        t.Fatalf("Expected verified pool creation to succeed, got: %s", err)
+    }
+    
+    if creates != 1 {
+        t.Errorf("Expected 1 create request, got %d", creates)
+    }
+}
+
+// TestRetryRenamePoolVerified tests that a rename whose response was lost is
+// not sent again under the old name once the pool shows up renamed
+func TestRetryRenamePoolVerified(t *testing.T) {
+    renames := 0
+    
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/pool/old", func(w http.ResponseWriter, r *http.Request) {
+        renames++
+        if renames > 1 {
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        // The mgr renamed the pool but the response never made it back
+        w.WriteHeader(http.StatusGatewayTimeout)
+    })
+    mux.HandleFunc("/api/pool", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode([]map[string]interface{}{{"pool": 7, "pool_name": "new"}})
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    if err := client.RenamePool(context.Background(), 7, "old", "new"); err != nil {
+        t.Fatalf("Expected verified pool rename to succeed, got: %s", err)
+    }
+    
+    if renames != 1 {
+        t.Errorf("Expected 1 rename request, got %d", renames)
+    }
+}
+
+// TestRetryNonIdempotent tests that unverifiable POST requests are not retried
+func TestRetryNonIdempotent(t *testing.T) {
+    attempts := 0
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        attempts++
+        w.WriteHeader(http.StatusServiceUnavailable)
+    }))
+    defer server.Close()
+    
+    client := newTestRetryClient(server)
+    
+    resp, err := client.doRequest(context.Background(), "POST", "/api/pool", nil)
+    if err != nil {
+        t.Fatalf("Expected response, got: %s", err)
+    }
+    resp.Body.Close()
+    
+    if attempts != 1 {
+        t.Errorf("Expected 1 attempt, got %d", attempts)
+    }
+}
+
+// TestParseRetryAfter tests both Retry-After formats
+func TestParseRetryAfter(t *testing.T) {
+    if wait, ok := parseRetryAfter("7"); !ok || wait != 7*time.Second {
+        t.Errorf("Expected 7s, got %s (%t)", wait, ok)
+    }
+    
+    date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+    if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > time.Minute {
+        t.Errorf("Expected wait of up to 1m, got %s (%t)", wait, ok)
+    }
+    
+    if _, ok := parseRetryAfter("soon"); ok {
+        t.Error("Expected invalid Retry-After to be ignored")
+    }
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// byteSizePattern matches sizes such as "1024", "500GiB", "1.5 TB" or "10G"
+var byteSizePattern = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)\s*$`)
+
+// byteSizeUnits maps unit suffixes to their multipliers. Like the Ceph CLI,
+// single letter units are binary; SI units with a B suffix are decimal.
+var byteSizeUnits = map[string]float64{
+    "":    1,
+    "b":   1,
+    "k":   1 << 10,
+    "m":   1 << 20,
+    "g":   1 << 30,
+    "t":   1 << 40,
+    "p":   1 << 50,
+    "e":   1 << 60,
+    "kib": 1 << 10,
+    "mib": 1 << 20,
+    "gib": 1 << 30,
+    "tib": 1 << 40,
+    "pib": 1 << 50,
+    "eib": 1 << 60,
+    "kb":  1e3,
+    "mb":  1e6,
+    "gb":  1e9,
+    "tb":  1e12,
+    "pb":  1e15,
+    "eb":  1e18,
+}
+
+// parseByteSize converts a human readable size into bytes
+func parseByteSize(s string) (int64, error) {
+    m := byteSizePattern.FindStringSubmatch(s)
+    if m == nil {
+        return 0, fmt.Errorf("invalid size %q: expected a number of bytes or a size such as \"500GiB\"", s)
+    }
+
+    multiplier, ok := byteSizeUnits[strings.ToLower(m[2])]
+    if !ok {
+        return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, m[2])
+    }
+
+    value, err := strconv.ParseFloat(m[1], 64)
+    if err != nil {
+        return 0, fmt.Errorf("invalid size %q: %w", s, err)
+    }
+
+    bytes := value * multiplier
+    if bytes > math.MaxInt64 {
+        return 0, fmt.Errorf("invalid size %q: too large", s)
+    }
+    if bytes != math.Trunc(bytes) {
+        return 0, fmt.Errorf("invalid size %q: not a whole number of bytes", s)
+    }
+
+    return int64(bytes), nil
+}
+
+// byteSizeValidator validates that a string is a size understood by parseByteSize
+type byteSizeValidator struct{}
+
+// Description describes the validation in plain text formatting
+func (v byteSizeValidator) Description(_ context.Context) string {
+    return "value must be a number of bytes or a size with a unit such as \"500GiB\""
+}
+
+// MarkdownDescription describes the validation in Markdown formatting
+func (v byteSizeValidator) MarkdownDescription(ctx context.Context) string {
+    return v.Description(ctx)
+}
+
+// ValidateString performs the validation
+func (v byteSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+    if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+        return
+    }
+
+    if _, err := parseByteSize(req.ConfigValue.ValueString()); err != nil {
+        resp.Diagnostics.AddAttributeError(req.Path, "Invalid Size", err.Error())
+    }
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+    "testing"
+)
+
+// TestParseByteSize tests the supported size formats
+func TestParseByteSize(t *testing.T) {
+    tests := map[string]int64{
+        "1024":    1024,
+        "500GiB":  500 << 30,
+        "500G":    500 << 30,
+        "1.5 TiB": 3 << 39,
+        "2GB":     2000000000,
+        "0":       0,
+    }
+    
+    for input, want := range tests {
+        got, err := parseByteSize(input)
+        if err != nil {
+            t.Errorf("%s: unexpected error: %s", input, err)
+            continue
+        }
+        if got != want {
+            t.Errorf("%s: expected %d, got %d", input, want, got)
+        }
+    }
+    
+    for _, input := range []string{"", "GiB", "10 XB", "-1", "0.5B"} {
+        if _, err := parseByteSize(input); err == nil {
+            t.Errorf("%s: expected error", input)
+        }
+    }
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// powerOfTwoValidator validates that a number is a power of two, as Ceph
+// expects for pg_num to keep placement groups evenly sized
+type powerOfTwoValidator struct{}
+
+// Description describes the validation in plain text formatting
+func (v powerOfTwoValidator) Description(_ context.Context) string {
+    return "value must be a power of two"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting
+func (v powerOfTwoValidator) MarkdownDescription(ctx context.Context) string {
+    return v.Description(ctx)
+}
+
+// ValidateInt64 performs the validation
+func (v powerOfTwoValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+    if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+        return
+    }
+
+    n := req.ConfigValue.ValueInt64()
+    if !isPowerOfTwo(n) {
+        resp.Diagnostics.AddAttributeError(
+            req.Path,
+            "Invalid Placement Group Count",
+            fmt.Sprintf("%d is not a power of two. Placement groups of unevenly split pools differ in size, which unbalances data across OSDs and raises a POOL_PG_NUM_NOT_POWER_OF_TWO health warning.", n),
+        )
+    }
+}
+
+// isPowerOfTwo reports whether n is a positive power of two
+func isPowerOfTwo(n int64) bool {
+    return n > 0 && n&(n-1) == 0
+}
+
+// rbdImageSpecValidator validates that a string is an image spec as returned
+// by rbdImageSpec
+type rbdImageSpecValidator struct{}
+
+// Description describes the validation in plain text formatting
+func (v rbdImageSpecValidator) Description(_ context.Context) string {
+    return "value must be an image spec, pool/namespace/image or pool/image"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting
+func (v rbdImageSpecValidator) MarkdownDescription(ctx context.Context) string {
+    return v.Description(ctx)
+}
+
+// ValidateString performs the validation
+func (v rbdImageSpecValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+    if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+        return
+    }
+
+    if _, _, _, err := parseRBDImageSpec(req.ConfigValue.ValueString()); err != nil {
+        resp.Diagnostics.AddAttributeError(
+            req.Path,
+            "Invalid RBD Image Spec",
+            err.Error(),
+        )
+    }
+}
+
+// rbdSnapshotSpecValidator validates that a string is a snapshot spec as
+// returned by rbdSnapshotSpec
+type rbdSnapshotSpecValidator struct{}
+
+// Description describes the validation in plain text formatting
+func (v rbdSnapshotSpecValidator) Description(_ context.Context) string {
+    return "value must be a snapshot spec, pool/namespace/image@snapshot or pool/image@snapshot"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting
+func (v rbdSnapshotSpecValidator) MarkdownDescription(ctx context.Context) string {
+    return v.Description(ctx)
+}
+
+// ValidateString performs the validation
+func (v rbdSnapshotSpecValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+    if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+        return
+    }
+
+    if _, _, err := parseRBDSnapshotSpec(req.ConfigValue.ValueString()); err != nil {
+        resp.Diagnostics.AddAttributeError(
+            req.Path,
+            "Invalid RBD Snapshot Spec",
+            err.Error(),
+        )
+    }
+}
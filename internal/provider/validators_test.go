@@ -0,0 +1,20 @@
+package provider
+
+import (
+    "testing"
+)
+
+// TestIsPowerOfTwo tests the pg_num power of two check
+func TestIsPowerOfTwo(t *testing.T) {
+    for _, n := range []int64{1, 2, 32, 1024} {
+        if !isPowerOfTwo(n) {
+            t.Errorf("%d: expected a power of two", n)
+        }
+    }
+
+    for _, n := range []int64{-2, 0, 3, 100} {
+        if isPowerOfTwo(n) {
+            t.Errorf("%d: expected no power of two", n)
+        }
+    }
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "path"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Ceph major versions the provider knows about
+const (
+    cephOctopus = 15
+    cephPacific = 16
+    cephQuincy  = 17
+    cephReef    = 18
+    cephSquid   = 19
+)
+
+// minSupportedCephVersion is the oldest release whose Dashboard API the
+// provider can talk to
+const minSupportedCephVersion = cephOctopus
+
+// defaultAPIVersion is the Dashboard API version requested unless an
+// endpoint is listed in apiVersions. Differences between releases in the
+// payloads of these endpoints are handled while decoding, see normalizePool.
+const defaultAPIVersion = "1.0"
+
+// cephReleaseNames maps Ceph major versions to release names
+var cephReleaseNames = map[int]string{
+    14:          "nautilus",
+    cephOctopus: "octopus",
+    cephPacific: "pacific",
+    cephQuincy:  "quincy",
+    cephReef:    "reef",
+    cephSquid:   "squid",
+}
+
+// apiVersions lists the endpoints the provider calls whose Dashboard API
+// version differs from defaultAPIVersion starting with a given Ceph release.
+// The Dashboard rejects requests for any other major version of an endpoint.
+// Paths are matched with path.Match, without query string.
+var apiVersions = []struct {
+    method  string
+    path    string
+    version string
+    since   int
+}{
+    // Quincy dropped ruleset from CRUSH rules, which CrushRule never decoded
+    {http.MethodGet, "/api/crush_rule", "2.0", cephQuincy},
+    {http.MethodGet, "/api/crush_rule/*", "2.0", cephQuincy},
+}
+
+// cephVersionPattern matches e.g. "ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)"
+var cephVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+
+// CephVersion identifies the Ceph release of the cluster
+type CephVersion struct {
+    Major int
+    Minor int
+    Patch int
+}
+
+// Release returns the release name, e.g. "quincy"
+func (v CephVersion) Release() string {
+    if name, ok := cephReleaseNames[v.Major]; ok {
+        return name
+    }
+    return "unknown"
+}
+
+func (v CephVersion) String() string {
+    return fmt.Sprintf("%d.%d.%d (%s)", v.Major, v.Minor, v.Patch, v.Release())
+}
+
+// Supported reports whether the provider can manage a cluster of this release
+func (v CephVersion) Supported() bool {
+    return v.Major >= minSupportedCephVersion
+}
+
+// VersionedAPI reports whether the Dashboard negotiates API versions through
+// vnd.ceph.api media types, which it does since Pacific
+func (v CephVersion) VersionedAPI() bool {
+    return v.Major >= cephPacific
+}
+
+// parseCephVersion extracts the version from a "ceph version" string
+func parseCephVersion(s string) (CephVersion, error) {
+    m := cephVersionPattern.FindStringSubmatch(s)
+    if m == nil {
+        return CephVersion{}, fmt.Errorf("unrecognized Ceph version %q", s)
+    }
+
+    var v CephVersion
+    v.Major, _ = strconv.Atoi(m[1])
+    v.Minor, _ = strconv.Atoi(m[2])
+    v.Patch, _ = strconv.Atoi(m[3])
+    return v, nil
+}
+
+// SummaryResponse is the subset of /api/summary used by the provider
+type SummaryResponse struct {
+    Version string `json:"version"`
+}
+
+// DetectVersion queries the Ceph release of the cluster and remembers it so
+// that subsequent requests use the matching API shapes
+func (c *CephClient) DetectVersion(ctx context.Context) (CephVersion, error) {
+    resp, err := c.doRequest(ctx, "GET", "/api/summary", nil)
+    if err != nil {
+        return CephVersion{}, fmt.Errorf("summary request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        bodyBytes, _ := io.ReadAll(resp.Body)
+        return CephVersion{}, fmt.Errorf("failed to get summary with status %d: %s", resp.StatusCode, string(bodyBytes))
+    }
+
+    var summary SummaryResponse
+    if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+        return CephVersion{}, fmt.Errorf("failed to decode summary response: %w", err)
+    }
+
+    version, err := parseCephVersion(summary.Version)
+    if err != nil {
+        return CephVersion{}, err
+    }
+
+    c.versionMu.Lock()
+    c.ServerVersion = &version
+    c.versionMu.Unlock()
+
+    return version, nil
+}
+
+// serverVersion returns the detected Ceph release, or nil if unknown
+func (c *CephClient) serverVersion() *CephVersion {
+    c.versionMu.RLock()
+    defer c.versionMu.RUnlock()
+
+    return c.ServerVersion
+}
+
+// acceptHeader returns the Accept header for a request. Releases without
+// API versioning get plain JSON, all others the versioned media type of
+// the endpoint.
+func (c *CephClient) acceptHeader(method, urlPath string) string {
+    v := c.serverVersion()
+    if v != nil && !v.VersionedAPI() {
+        return "application/json"
+    }
+
+    return "application/vnd.ceph.api.v" + apiVersionFor(v, method, urlPath) + "+json"
+}
+
+// apiVersionFor returns the Dashboard API version of an endpoint on the
+// given release. If the release is not known yet the newest version is used.
+func apiVersionFor(server *CephVersion, method, urlPath string) string {
+    urlPath, _, _ = strings.Cut(urlPath, "?")
+
+    for _, v := range apiVersions {
+        if v.method != method {
+            continue
+        }
+        if ok, _ := path.Match(v.path, urlPath); !ok {
+            continue
+        }
+        if server == nil || server.Major >= v.since {
+            return v.version
+        }
+    }
+    return defaultAPIVersion
+}
+
+// poolTypeNames maps the numeric pool types of the OSD map to their names
+var poolTypeNames = map[float64]string{
+    1: poolTypeReplicated,
+    3: poolTypeErasure,
+}
+
+// normalizePool rewrites the pool payload of any supported release into the
+// shape the provider expects: type and crush_rule as names, pgp_num set, and
+// application_metadata as a map of application to its metadata.
+func normalizePool(pool map[string]interface{}) {
+    if t, ok := pool["type"].(float64); ok {
+        if name, ok := poolTypeNames[t]; ok {
+            pool["type"] = name
+        }
+    }
+
+    // Only the Dashboard resolves the CRUSH rule id to its name
+    if rule, ok := pool["crush_rule"].(float64); ok {
+        pool["crush_rule"] = strconv.FormatFloat(rule, 'f', -1, 64)
+    }
+
+    if _, ok := pool["pgp_num"]; !ok {
+        if pgpNum, ok := pool["pg_placement_num"]; ok {
+            pool["pgp_num"] = pgpNum
+        }
+    }
+
+    // The Dashboard reduces the metadata to the list of application names
+    if apps, ok := pool["application_metadata"].([]interface{}); ok {
+        metadata := make(map[string]interface{}, len(apps))
+        for _, app := range apps {
+            if name, ok := app.(string); ok {
+                metadata[name] = map[string]interface{}{}
+            }
+        }
+        pool["application_metadata"] = metadata
+    }
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+// TestParseCephVersion tests parsing of "ceph version" strings
+func TestParseCephVersion(t *testing.T) {
+    v, err := parseCephVersion("ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)")
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    if v.Major != 17 || v.Minor != 2 || v.Patch != 6 || v.Release() != "quincy" {
+        t.Errorf("Expected 17.2.6 (quincy), got %s", v)
+    }
+    
+    if _, err := parseCephVersion("unknown"); err == nil {
+        t.Error("Expected invalid version to be rejected")
+    }
+}
+
+// TestAcceptHeader tests the media type sent to each release and endpoint
+func TestAcceptHeader(t *testing.T) {
+    tests := []struct {
+        major  int
+        method string
+        path   string
+        want   string
+    }{
+        {cephOctopus, "GET", "/api/crush_rule", "application/json"},
+        {cephPacific, "GET", "/api/pool/test", "application/vnd.ceph.api.v1.0+json"},
+        {cephPacific, "GET", "/api/crush_rule", "application/vnd.ceph.api.v1.0+json"},
+        {cephPacific, "GET", "/api/crush_rule/ssd_rule", "application/vnd.ceph.api.v1.0+json"},
+        {cephQuincy, "GET", "/api/crush_rule", "application/vnd.ceph.api.v2.0+json"},
+        {cephQuincy, "GET", "/api/crush_rule/ssd_rule", "application/vnd.ceph.api.v2.0+json"},
+        {cephReef, "GET", "/api/crush_rule?name=ssd", "application/vnd.ceph.api.v2.0+json"},
+        {cephReef, "POST", "/api/crush_rule", "application/vnd.ceph.api.v1.0+json"},
+        {cephReef, "DELETE", "/api/crush_rule/ssd_rule", "application/vnd.ceph.api.v1.0+json"},
+        {cephReef, "GET", "/api/crush_rule/ssd_rule/steps", "application/vnd.ceph.api.v1.0+json"},
+        {cephSquid, "GET", "/api/pool/test", "application/vnd.ceph.api.v1.0+json"},
+    }
+    
+    for _, tt := range tests {
+        client := NewCephClient("https://localhost:8443", "admin", "password")
+        client.ServerVersion = &CephVersion{Major: tt.major}
+        
+        if got := client.acceptHeader(tt.method, tt.path); got != tt.want {
+            t.Errorf("%d %s %s: expected '%s', got '%s'", tt.major, tt.method, tt.path, tt.want, got)
+        }
+    }
+    
+    // Before the release is detected the newest versions are requested
+    client := NewCephClient("https://localhost:8443", "admin", "password")
+    if got := client.acceptHeader("GET", "/api/crush_rule"); got != "application/vnd.ceph.api.v2.0+json" {
+        t.Errorf("Expected the newest version for an unknown release, got '%s'", got)
+    }
+}
+
+// TestDetectVersion tests that the release is read from /api/summary
+func TestDetectVersion(t *testing.T) {
+    mux := http.NewServeMux()
+    mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+        json.NewEncoder(w).Encode(AuthResponse{Token: "token"})
+    })
+    mux.HandleFunc("/api/summary", func(w http.ResponseWriter, r *http.Request) {
+        if accept := r.Header.Get("Accept"); accept != "application/vnd.ceph.api.v1.0+json" {
+            t.Errorf("Expected versioned Accept header, got '%s'", accept)
+        }
+        json.NewEncoder(w).Encode(SummaryResponse{Version: "ceph version 18.2.1 (7fe91d5d5842e04be3b4f514d6dd990c54b29c76) reef (stable)"})
+    })
+    server := httptest.NewServer(mux)
+    defer server.Close()
+    
+    client := NewCephClient(server.URL, "admin", "password")
+    
+    v, err := client.DetectVersion(context.Background())
+    if err != nil {
+        t.Fatal(err)
+    }
+    
+    if v.Major != cephReef || client.ServerVersion == nil {
+        t.Errorf("Expected Reef to be detected and remembered, got %s", v)
+    }
+}
+
+// TestNormalizePool tests that Dashboard specific pool fields are mapped
+func TestNormalizePool(t *testing.T) {
+    pool := map[string]interface{}{
+        "type":                 float64(1),
+        "pg_placement_num":     float64(32),
+        "application_metadata": []interface{}{"rbd", "rgw"},
+    }
+    normalizePool(pool)
+    
+    if pool["type"] != "replicated" {
+        t.Errorf("Expected type 'replicated', got %v", pool["type"])
+    }
+    
+    if pool["pgp_num"] != float64(32) {
+        t.Errorf("Expected pgp_num 32, got %v", pool["pgp_num"])
+    }
+    
+    apps, ok := pool["application_metadata"].(map[string]interface{})
+    if !ok || len(apps) != 2 {
+        t.Errorf("Expected application metadata map with 2 entries, got %v", pool["application_metadata"])
+    }
+}